@@ -2,7 +2,9 @@ package app
 
 import (
 	"context"
+	stderrors "errors"
 	"flag"
+	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/configure"
 	"github.com/go-kid/ioc/container"
 	"github.com/go-kid/ioc/container/factory"
@@ -12,6 +14,8 @@ import (
 	"github.com/go-kid/ioc/syslog"
 	"github.com/go-kid/ioc/util/framework_helper"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"strings"
 	"sync"
 	"time"
 )
@@ -19,12 +23,24 @@ import (
 type App struct {
 	configure.Configure
 	container.Factory
-	registry           container.SingletonRegistry
-	shutdownTimeout    time.Duration
-	skipRunners        bool
-	ApplicationRunners []definition.ApplicationRunner       `wire:",required=false"`
-	CloserComponents   []definition.CloserComponent         `wire:",required=false"`
-	EventListeners     []definition.ApplicationEventListener `wire:",required=false"`
+	registry            container.SingletonRegistry
+	shutdownTimeout     time.Duration
+	skipRunners         bool
+	scopes              map[string]container.Scope
+	strictWiring        bool
+	refreshWorkers      int
+	aggregateErrors     bool
+	disallowCircular    bool
+	measureStartup      bool
+	listenerMu          sync.RWMutex
+	listeners           []*eventListener
+	listenersRegistered bool
+	pendingEvents       []definition.ApplicationEvent
+	optionErrors        []error
+	ApplicationRunners  []definition.ApplicationRunner         `wire:",required=false"`
+	CloserComponents    []definition.CloserComponent           `wire:",required=false"`
+	EventListeners      []definition.ApplicationEventListener  `wire:",required=false"`
+	Multicaster         definition.ApplicationEventMulticaster `wire:",required=false"`
 }
 
 func NewApp() *App {
@@ -47,8 +63,66 @@ func (s *App) initiate() error {
 	if s.Factory == nil {
 		return errors.New("missing factory")
 	}
+	if len(s.optionErrors) != 0 {
+		return stderrors.Join(s.optionErrors...)
+	}
+	if _, ok := s.Factory.(container.SingletonLister); !ok {
+		return errors.Errorf("factory %T does not support listing the singletons", s.Factory)
+	}
+	if _, ok := s.Factory.(container.SingletonDestroyer); !ok {
+		return errors.Errorf("factory %T does not support destroying the singletons", s.Factory)
+	}
 	s.Factory.SetRegistry(s.registry)
 	s.Factory.SetConfigure(s.Configure)
+	if len(s.scopes) != 0 {
+		sf, ok := s.Factory.(container.ScopedFactory)
+		if !ok {
+			return errors.Errorf("factory %T does not support custom scopes", s.Factory)
+		}
+		for name, scope := range s.scopes {
+			sf.RegisterScope(name, scope)
+		}
+	}
+	dependencyFurtherMatchingProcessors := processors.NewDependencyFurtherMatchingProcessors()
+	if s.strictWiring {
+		sw, ok := s.Factory.(strictWiringSetter)
+		if !ok {
+			return errors.Errorf("factory %T does not support strict wiring", s.Factory)
+		}
+		sw.SetStrictWiring(true)
+		dependencyFurtherMatchingProcessors = processors.NewStrictDependencyFurtherMatchingProcessors()
+	}
+	if s.refreshWorkers > 1 {
+		ps, ok := s.Factory.(parallelismSetter)
+		if !ok {
+			return errors.Errorf("factory %T does not support parallel refresh", s.Factory)
+		}
+		ps.SetParallelism(s.refreshWorkers)
+	}
+	if s.aggregateErrors {
+		as, ok := s.Factory.(aggregateErrorsSetter)
+		if !ok {
+			return errors.Errorf("factory %T does not support aggregating refresh errors", s.Factory)
+		}
+		as.SetAggregateErrors(true)
+	}
+	if s.disallowCircular {
+		cs, ok := s.Factory.(circularReferencesSetter)
+		if !ok {
+			return errors.Errorf("factory %T does not support disallowing circular references", s.Factory)
+		}
+		cs.SetAllowCircularReferences(false)
+	}
+	if s.measureStartup {
+		ms, ok := s.Factory.(startupMeasureSetter)
+		if !ok {
+			return errors.Errorf("factory %T does not support measuring the startup", s.Factory)
+		}
+		ms.SetMeasureStartup(true)
+	}
+	if obs, ok := s.Factory.(componentObserverSetter); ok {
+		obs.SetComponentObserver(&componentEventObserver{app: s})
+	}
 	var initiateComponent = []any{
 		s,
 		processors.NewLoggerAwarePostProcessor(),
@@ -58,7 +132,7 @@ func (s *App) initiate() error {
 		processors.NewValueAwarePostProcessors(),
 		processors.NewValidateAwarePostProcessors(),
 		processors.NewDependencyAwarePostProcessors(),
-		processors.NewDependencyFurtherMatchingProcessors(),
+		dependencyFurtherMatchingProcessors,
 		processors.NewDependencyFunctionAwarePostProcessors(),
 	}
 	for _, c := range initiateComponent {
@@ -67,6 +141,26 @@ func (s *App) initiate() error {
 	return nil
 }
 
+type strictWiringSetter interface {
+	SetStrictWiring(strict bool)
+}
+
+type parallelismSetter interface {
+	SetParallelism(workers int)
+}
+
+type aggregateErrorsSetter interface {
+	SetAggregateErrors(aggregate bool)
+}
+
+type circularReferencesSetter interface {
+	SetAllowCircularReferences(allow bool)
+}
+
+type startupMeasureSetter interface {
+	SetMeasureStartup(measure bool)
+}
+
 type contextSetter interface {
 	SetContext(ctx context.Context)
 }
@@ -76,51 +170,139 @@ func (s *App) Run(ops ...SettingOption) error {
 }
 
 func (s *App) RunWithContext(ctx context.Context, ops ...SettingOption) error {
+	start := time.Now()
+	err := s.prepare(ctx, ops)
+	if err == nil {
+		err = s.run(ctx, start)
+	}
+	if err != nil {
+		s.logger().Errorf("application run failed: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// prepare applies the options with the global ones, sets the factory up, then loads the configuration
+// and prepares the components, it is shared by RunWithContext and ValidateWithContext.
+func (s *App) prepare(ctx context.Context, ops []SettingOption) error {
 	allOps := append(ops, globalOptions...)
 	globalOptions = nil
 	for _, op := range allOps {
 		op(s)
 	}
-	err := s.initiate()
-	if err != nil {
-		s.logger().Fatalf("%+v", err)
+	if err := s.initiate(); err != nil {
+		return err
 	}
 	if cs, ok := s.Factory.(contextSetter); ok {
 		cs.SetContext(ctx)
 	}
-	if err := s.run(ctx); err != nil {
-		s.logger().Errorf("application run failed: %+v", err)
-		return err
+	if err := s.checkOverrides(); err != nil {
+		return errors.WithMessage(err, "application components registration failed")
 	}
-	return nil
-}
 
-func (s *App) run(ctx context.Context) error {
 	s.logger().Info("start initializing configuration...")
+	phase := time.Now()
 	if err := s.initConfiguration(); err != nil {
 		return errors.WithMessage(err, "application configuration initialize failed")
 	}
+	s.publishLifecycleEvent(&definition.ConfigurationLoadedEvent{App: s, Elapsed: time.Since(phase)})
 
 	s.logger().Info("start initializing component factory...")
 	if err := s.initFactory(); err != nil {
 		return errors.WithMessage(err, "application factory initialize failed")
 	}
+	return nil
+}
 
+// run refreshes the prepared components and calls the runners, start is the time the application started running
+func (s *App) run(ctx context.Context, start time.Time) error {
 	s.logger().Info("start refreshing components...")
+	phase := time.Now()
 	if err := s.refresh(); err != nil {
 		return errors.WithMessage(err, "application components refresh failed")
 	}
+	refreshed := time.Since(phase)
+	s.registerEventListeners()
+	s.publishPendingEvents()
+	s.publishLifecycleEvent(&definition.RefreshFinishedEvent{App: s, Components: len(s.singletons()), Elapsed: refreshed})
+
+	if reporter, ok := s.Factory.(container.StartupReporter); ok && s.measureStartup {
+		s.logger().Debugf("slowest components to create:\n%s", reporter.GetStartupReport().Table(startupReportSize))
+	}
+	if refs := s.CircularReferences(); len(refs) != 0 {
+		paths := lo.Map(refs, func(ref container.CircularReference, _ int) string { return ref.Path() })
+		s.logger().Warnf("%d circular references resolved by early references, forbid them by app.DisallowCircularReferences():\n  %s",
+			len(refs), strings.Join(paths, "\n  "))
+	}
 
 	s.logger().Info("start call up runners...")
 	if err := s.callRunners(ctx); err != nil {
 		return errors.WithMessagef(err, "start application runners failed")
 	}
 
-	_ = s.PublishEvent(&definition.ApplicationStartedEvent{App: s})
+	s.publishLifecycleEvent(&definition.ApplicationStartedEvent{App: s})
+	s.publishLifecycleEvent(&definition.ApplicationReadyEvent{App: s, Elapsed: time.Since(start)})
 	s.logger().Info("application run up")
 	return nil
 }
 
+// startupReportSize is the number of the slowest components logged at debug level after refresh
+const startupReportSize = 10
+
+// StartupReport returns the time spent creating each component and in each ComponentPostProcessor,
+// sorted from the slowest, or nil unless the application runs with MeasureStartup.
+func (s *App) StartupReport() *container.StartupReport {
+	if reporter, ok := s.Factory.(container.StartupReporter); ok && s.measureStartup {
+		return reporter.GetStartupReport()
+	}
+	return nil
+}
+
+// CircularReferences returns the circular references resolved by early references during refresh,
+// or nil if the factory does not report them.
+func (s *App) CircularReferences() []container.CircularReference {
+	if reporter, ok := s.Factory.(container.CircularReferenceReporter); ok {
+		return reporter.GetCircularReferences()
+	}
+	return nil
+}
+
+// GetComponentByNameWithContext is like GetComponentByName, but resolves custom scoped components against ctx
+func (s *App) GetComponentByNameWithContext(ctx context.Context, name string) (any, error) {
+	sf, ok := s.Factory.(container.ScopedFactory)
+	if !ok {
+		return nil, errors.Errorf("factory %T does not support custom scopes", s.Factory)
+	}
+	return sf.GetComponentByNameWithContext(ctx, name)
+}
+
+// singletons returns the singletons created by the factory, initiate checks that the factory lists them
+func (s *App) singletons() []*component_definition.Meta {
+	if lister, ok := s.Factory.(container.SingletonLister); ok {
+		return lister.GetSingletons()
+	}
+	return nil
+}
+
+// checkOverrides fails on the duplicated registrations rejected by container.OverrideError,
+// registries not supporting override policies handle the duplicated registrations themselves.
+func (s *App) checkOverrides() error {
+	registry, ok := s.registry.(container.OverrideRegistry)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, override := range registry.GetOverrides() {
+		if override.Policy == container.OverrideError {
+			names = append(names, override.Name)
+		}
+	}
+	if len(names) > 0 {
+		return errors.Errorf("register duplicated components %v", names)
+	}
+	return nil
+}
+
 func (s *App) initConfiguration() error {
 	err := s.Configure.Initialize()
 	if err != nil {
@@ -148,11 +330,14 @@ func (s *App) refresh() error {
 func (s *App) callRunners(ctx context.Context) error {
 	if s.skipRunners {
 		s.logger().Info("skip runners")
+		s.publishLifecycleEvent(&definition.RunnersStartedEvent{App: s})
 		return nil
 	}
+	start := time.Now()
 	runners := s.ApplicationRunners
 	if len(runners) == 0 {
 		s.logger().Trace("find 0 application runner, skip")
+		s.publishLifecycleEvent(&definition.RunnersStartedEvent{App: s})
 		return nil
 	}
 	s.logger().Tracef("find %d application runner(s), start sort", len(runners))
@@ -160,6 +345,7 @@ func (s *App) callRunners(ctx context.Context) error {
 	for i := range runners {
 		runner := runners[i]
 		s.logger().Tracef("start runner %T [%d/%d]", runner, i+1, len(runners))
+		runnerStart := time.Now()
 		var err error
 		if r, ok := runner.(definition.ApplicationRunnerWithContext); ok {
 			err = r.RunWithContext(ctx)
@@ -167,10 +353,12 @@ func (s *App) callRunners(ctx context.Context) error {
 			err = runner.Run()
 		}
 		if err != nil {
+			s.publishLifecycleEvent(&definition.RunnerFailedEvent{App: s, Runner: runner, Err: err, Elapsed: time.Since(runnerStart)})
 			return errors.Wrapf(err, "invoking Run() for runner '%T'", runner)
 		}
 	}
 	s.ApplicationRunners = nil
+	s.publishLifecycleEvent(&definition.RunnersStartedEvent{App: s, Runners: len(runners), Elapsed: time.Since(start)})
 	s.logger().Info("all runners started")
 	return nil
 }
@@ -179,46 +367,27 @@ func (s *App) Close() {
 	s.CloseWithContext(context.Background())
 }
 
-func (s *App) PublishEvent(event definition.ApplicationEvent) error {
-	for _, listener := range s.EventListeners {
-		if err := listener.OnEvent(event); err != nil {
-			s.logger().Errorf("event listener %T failed: %+v", listener, err)
-			return errors.Wrapf(err, "event listener %T failed", listener)
-		}
-	}
-	return nil
-}
-
 func (s *App) CloseWithContext(ctx context.Context) {
-	_ = s.PublishEvent(&definition.ApplicationClosingEvent{App: s})
+	start := time.Now()
+	s.publishLifecycleEvent(&definition.ApplicationClosingEvent{App: s})
 	if s.shutdownTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, s.shutdownTimeout)
 		defer cancel()
 	}
+	s.logger().Infof("destroy singleton components")
+	if destroyer, ok := s.Factory.(container.SingletonDestroyer); ok {
+		if err := destroyer.DestroySingletons(); err != nil {
+			s.logger().Errorf("destroy singleton components failed: %+v", err)
+		}
+	}
 	s.logger().Infof("close closer components")
 	if len(s.CloserComponents) != 0 {
-		wg := sync.WaitGroup{}
-		wg.Add(len(s.CloserComponents))
-		for _, m := range s.CloserComponents {
-			go func(m definition.CloserComponent) {
-				defer wg.Done()
-				var err error
-				if c, ok := m.(definition.CloserComponentWithContext); ok {
-					err = c.CloseWithContext(ctx)
-				} else {
-					err = m.Close()
-				}
-				if err != nil {
-					err = errors.Wrapf(err, "invoking Close() for closer '%T'", m)
-					s.logger().Errorf("%+v", err)
-				}
-			}(m)
-		}
-		wg.Wait()
+		s.closeComponents(ctx)
 	} else {
 		s.logger().Trace("find 0 closer component, skip")
 	}
+	s.publishLifecycleEvent(&definition.ApplicationClosedEvent{App: s, Elapsed: time.Since(start)})
 }
 
 func (s *App) logger() syslog.Logger {
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/go-kid/ioc/definition"
+	"github.com/go-kid/ioc/util/framework_helper"
+)
+
+var (
+	applicationEventType = reflect.TypeOf((*definition.ApplicationEvent)(nil)).Elem()
+	errorType            = reflect.TypeOf((*error)(nil)).Elem()
+	boolType             = reflect.TypeOf(false)
+)
+
+// eventListener is a listener of the events assignable to eventType
+type eventListener struct {
+	// listener is the listening component or function
+	listener  any
+	eventType reflect.Type
+	onEvent   func(event definition.ApplicationEvent) error
+	// condition filters the accepted events, nil accepts all of them
+	condition func(event definition.ApplicationEvent) bool
+	// phase is the end of a unit of work the listener receives the events at, zero when they are published
+	phase definition.TransactionPhase
+}
+
+func (l *eventListener) OnEvent(event definition.ApplicationEvent) error {
+	return l.onEvent(event)
+}
+
+// HandleEventError lets the listening component handle its error if it is a definition.EventErrorHandler
+func (l *eventListener) HandleEventError(event definition.ApplicationEvent, err error) error {
+	if h, ok := l.listener.(definition.EventErrorHandler); ok {
+		return h.HandleEventError(event, err)
+	}
+	return err
+}
+
+func (l *eventListener) accepts(event definition.ApplicationEvent) bool {
+	t := reflect.TypeOf(event)
+	return t != nil && t.AssignableTo(l.eventType)
+}
+
+func (l *eventListener) matches(event definition.ApplicationEvent) bool {
+	return l.condition == nil || l.condition(event)
+}
+
+// newEventListener returns the listener of a component implementing definition.ApplicationEventListener
+// or definition.EventListener of a single event type, filtered by its definition.ConditionalEventListener method.
+func newEventListener(component any) (*eventListener, bool) {
+	var l *eventListener
+	if el, ok := component.(definition.ApplicationEventListener); ok {
+		l = &eventListener{listener: component, eventType: applicationEventType, onEvent: el.OnEvent}
+	} else {
+		method := reflect.ValueOf(component).MethodByName("OnEvent")
+		if !method.IsValid() {
+			return nil, false
+		}
+		methodType := method.Type()
+		if methodType.NumIn() != 1 || methodType.NumOut() != 1 || methodType.Out(0) != errorType {
+			return nil, false
+		}
+		eventType := methodType.In(0)
+		if eventType.Kind() != reflect.Interface && !eventType.Implements(applicationEventType) {
+			return nil, false
+		}
+		l = &eventListener{
+			listener:  component,
+			eventType: eventType,
+			onEvent: func(event definition.ApplicationEvent) error {
+				err, _ := method.Call([]reflect.Value{reflect.ValueOf(event)})[0].Interface().(error)
+				return err
+			},
+		}
+	}
+	if condition := reflect.ValueOf(component).MethodByName("Condition"); condition.IsValid() {
+		conditionType := condition.Type()
+		if conditionType.NumIn() == 1 && conditionType.NumOut() == 1 && conditionType.Out(0) == boolType &&
+			l.eventType.AssignableTo(conditionType.In(0)) {
+			l.condition = func(event definition.ApplicationEvent) bool {
+				return condition.Call([]reflect.Value{reflect.ValueOf(event)})[0].Bool()
+			}
+		}
+	}
+	if tl, ok := component.(definition.TransactionalEventListener); ok {
+		l.phase = tl.TransactionPhase()
+	}
+	return l, true
+}
+
+// registerEventListeners registers the singletons listening to events and the other EventListeners,
+// PriorityOrdered first, then Ordered by their order, then the others by component name.
+func (s *App) registerEventListeners() {
+	var (
+		components []any
+		registered = map[any]bool{}
+	)
+	singletons := s.singletons()
+	sort.Slice(singletons, func(i, j int) bool { return singletons[i].Name() < singletons[j].Name() })
+	for _, singleton := range singletons {
+		components = append(components, singleton.Raw)
+		if reflect.TypeOf(singleton.Raw).Comparable() {
+			registered[singleton.Raw] = true
+		}
+	}
+	for _, l := range s.EventListeners {
+		if !reflect.TypeOf(l).Comparable() || !registered[l] {
+			components = append(components, l)
+		}
+	}
+	var listeners []*eventListener
+	for _, component := range framework_helper.SortOrderedComponents(components) {
+		if l, ok := newEventListener(component); ok {
+			s.logger().Debugf("register listener %T of event %s", component, l.eventType)
+			listeners = append(listeners, l)
+		}
+	}
+	s.listenerMu.Lock()
+	s.listeners = append(s.listeners, listeners...)
+	s.listenerMu.Unlock()
+}
+
+// earlyListeners returns the listeners of the injected EventListeners, they receive the events published
+// during the refresh, before the listening components are registered.
+func (s *App) earlyListeners() []*eventListener {
+	var listeners []*eventListener
+	for _, component := range framework_helper.SortOrderedComponents(s.EventListeners) {
+		if l, ok := newEventListener(component); ok {
+			listeners = append(listeners, l)
+		}
+	}
+	return listeners
+}
+
+// Subscribe registers listener for the events assignable to eventType, an event type or an interface
+// implemented by a family of events. The returned function removes the listener.
+func (s *App) Subscribe(eventType reflect.Type, listener func(event definition.ApplicationEvent) error) (unsubscribe func()) {
+	l := &eventListener{listener: listener, eventType: eventType, onEvent: listener}
+	s.listenerMu.Lock()
+	s.listeners = append(s.listeners, l)
+	s.listenerMu.Unlock()
+	return func() {
+		s.listenerMu.Lock()
+		s.listeners = slices.DeleteFunc(s.listeners, func(e *eventListener) bool { return e == l })
+		s.listenerMu.Unlock()
+	}
+}
+
+// PublishEvent delivers event to the listeners accepting its type and matching their condition in registration order, by the
+// definition.ApplicationEventMulticaster component if there is one, otherwise synchronously until a listener fails.
+// The definition.TransactionalEventListener do not receive it, see PublishEventWithContext.
+// Before the listeners are registered at the end of the refresh, the injected EventListeners receive it.
+func (s *App) PublishEvent(event definition.ApplicationEvent) error {
+	return s.multicast(event, func(phase definition.TransactionPhase) bool { return phase == 0 })
+}
+
+// multicast delivers event to the listeners accepting it at a phase selected by inPhase
+func (s *App) multicast(event definition.ApplicationEvent, inPhase func(phase definition.TransactionPhase) bool) error {
+	s.listenerMu.RLock()
+	registered := s.listeners
+	if !s.listenersRegistered {
+		registered = append(s.earlyListeners(), registered...)
+	}
+	var candidates []*eventListener
+	for _, l := range registered {
+		if inPhase(l.phase) && l.accepts(event) {
+			candidates = append(candidates, l)
+		}
+	}
+	s.listenerMu.RUnlock()
+	var listeners []definition.ApplicationEventListener
+	for _, l := range candidates {
+		if l.matches(event) {
+			listeners = append(listeners, l)
+		}
+	}
+	if len(listeners) == 0 {
+		return nil
+	}
+	multicaster := s.Multicaster
+	if multicaster == nil {
+		multicaster = defaultMulticaster
+	}
+	if err := multicaster.MulticastEvent(event, listeners); err != nil {
+		s.logger().Errorf("publish event %T failed: %+v", event, err)
+		return err
+	}
+	return nil
+}
+
+var defaultMulticaster = NewEventMulticaster()
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+
+	"github.com/go-kid/ioc/definition"
+	"github.com/pkg/errors"
+)
+
+type eventBufferKey struct{}
+
+// eventBuffer keeps the events published within a unit of work until it ends
+type eventBuffer struct {
+	mu      sync.Mutex
+	events  []definition.ApplicationEvent
+	flushed bool
+}
+
+// WithEventBuffer starts a unit of work bound to the returned context, the events published by PublishEventWithContext
+// with it are kept for the definition.TransactionalEventListener until App.FlushEvents is called when the unit of work ends.
+//
+//	ctx = app.WithEventBuffer(ctx)
+//	err := service.PlaceOrder(ctx, order)
+//	_ = application.FlushEvents(ctx, err == nil)
+func WithEventBuffer(ctx context.Context) context.Context {
+	return context.WithValue(ctx, eventBufferKey{}, &eventBuffer{})
+}
+
+func eventBufferOf(ctx context.Context) *eventBuffer {
+	buffer, _ := ctx.Value(eventBufferKey{}).(*eventBuffer)
+	return buffer
+}
+
+func (b *eventBuffer) add(event definition.ApplicationEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.flushed {
+		return errors.Errorf("publish event %T after the unit of work ended", event)
+	}
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *eventBuffer) drain() []definition.ApplicationEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	events := b.events
+	b.events, b.flushed = nil, true
+	return events
+}
+
+// PublishEventWithContext delivers event to the listeners like PublishEvent, and keeps it for the
+// definition.TransactionalEventListener if ctx is bound to a unit of work by WithEventBuffer.
+func (s *App) PublishEventWithContext(ctx context.Context, event definition.ApplicationEvent) error {
+	if buffer := eventBufferOf(ctx); buffer != nil {
+		if err := buffer.add(event); err != nil {
+			return err
+		}
+	}
+	return s.PublishEvent(event)
+}
+
+// FlushEvents ends the unit of work bound to ctx, its events are delivered in publication order to the
+// definition.TransactionalEventListener of definition.AfterCommit if committed, otherwise of definition.AfterRollback,
+// and to those of definition.AfterCompletion. A failed delivery does not stop the others, the errors are returned joined.
+func (s *App) FlushEvents(ctx context.Context, committed bool) error {
+	buffer := eventBufferOf(ctx)
+	if buffer == nil {
+		return errors.New("context is not bound to a unit of work, see app.WithEventBuffer")
+	}
+	phase := definition.AfterRollback
+	if committed {
+		phase = definition.AfterCommit
+	}
+	var errs []error
+	for _, event := range buffer.drain() {
+		err := s.multicast(event, func(p definition.TransactionPhase) bool {
+			return p == phase || p == definition.AfterCompletion
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return stderrors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"time"
+
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/definition"
+)
+
+type componentObserverSetter interface {
+	SetComponentObserver(observer container.ComponentObserver)
+}
+
+// componentEventObserver publishes the component events of the factory
+type componentEventObserver struct {
+	app *App
+}
+
+func (o *componentEventObserver) ComponentCreated(name string, meta *component_definition.Meta, elapsed time.Duration) {
+	o.app.publishLifecycleEvent(&definition.ComponentCreatedEvent{
+		ComponentName: name,
+		Component:     meta.Raw,
+		Type:          meta.Type,
+		Scope:         meta.Scope(),
+		Elapsed:       elapsed,
+	})
+}
+
+func (o *componentEventObserver) ComponentInitialized(name string, meta *component_definition.Meta, elapsed time.Duration) {
+	o.app.publishLifecycleEvent(&definition.ComponentInitializedEvent{
+		ComponentName: name,
+		Component:     meta.Raw,
+		Type:          meta.Type,
+		Scope:         meta.Scope(),
+		Elapsed:       elapsed,
+	})
+}
+
+// publishLifecycleEvent publishes a built-in event, the events published before the listeners are registered
+// are kept and published in order once they are.
+func (s *App) publishLifecycleEvent(event definition.ApplicationEvent) {
+	s.listenerMu.Lock()
+	if !s.listenersRegistered {
+		s.pendingEvents = append(s.pendingEvents, event)
+		s.listenerMu.Unlock()
+		return
+	}
+	s.listenerMu.Unlock()
+	_ = s.PublishEvent(event)
+}
+
+// publishPendingEvents publishes the built-in events kept until the listeners are registered
+func (s *App) publishPendingEvents() {
+	s.listenerMu.Lock()
+	s.listenersRegistered = true
+	pending := s.pendingEvents
+	s.pendingEvents = nil
+	s.listenerMu.Unlock()
+	for _, event := range pending {
+		_ = s.PublishEvent(event)
+	}
+}
@@ -0,0 +1,223 @@
+package app
+
+import (
+	stderrors "errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-kid/ioc/definition"
+	"github.com/go-kid/ioc/syslog"
+	"github.com/pkg/errors"
+)
+
+// ErrorPolicy decides what a multicaster does when a listener fails
+type ErrorPolicy int
+
+const (
+	// StopOnError stops the delivery at the first failed listener and returns its error
+	StopOnError ErrorPolicy = iota
+	// ContinueOnError delivers the event to every listener and returns the errors joined
+	ContinueOnError
+)
+
+// OverflowPolicy decides what an asynchronous multicaster does with a delivery when its queue is full
+type OverflowPolicy int
+
+const (
+	// BlockOnOverflow blocks the publisher until the queue has room
+	BlockOnOverflow OverflowPolicy = iota
+	// DropOnOverflow drops the delivery and logs it
+	DropOnOverflow
+	// CallerRunsOnOverflow delivers the event on the publishing goroutine
+	CallerRunsOnOverflow
+)
+
+// defaultQueueSize is the number of deliveries an asynchronous multicaster queues by default
+const defaultQueueSize = 256
+
+// EventErrorHandlerFunc handles the error of a listener, the returned error is handled by the error policy, nil ignores err
+type EventErrorHandlerFunc func(event definition.ApplicationEvent, listener any, err error) error
+
+type MulticasterOption func(*eventMulticaster)
+
+// WithErrorPolicy sets the error policy of a synchronous multicaster, StopOnError by default
+func WithErrorPolicy(policy ErrorPolicy) MulticasterOption {
+	return func(m *eventMulticaster) {
+		m.policy = policy
+	}
+}
+
+// WithErrorHandler handles the errors of all the listeners, after their own definition.EventErrorHandler
+func WithErrorHandler(handler EventErrorHandlerFunc) MulticasterOption {
+	return func(m *eventMulticaster) {
+		m.errorHandler = handler
+	}
+}
+
+// WithAsync delivers the events on workers long-lived goroutines draining a bounded queue, PublishEvent returns
+// once every delivery is queued, see WithQueue for a full queue. The errors are not returned to the publisher,
+// they are handled by the error handlers and logged. workers <= 0 keeps the delivery synchronous.
+func WithAsync(workers int) MulticasterOption {
+	return func(m *eventMulticaster) {
+		m.workers = workers
+	}
+}
+
+// WithQueue sets the number of deliveries an asynchronous multicaster queues, 256 by default,
+// and what it does with a delivery when the queue is full, BlockOnOverflow by default.
+// A listener publishing events from a worker should not block on overflow, it may wait for itself.
+func WithQueue(size int, overflow OverflowPolicy) MulticasterOption {
+	return func(m *eventMulticaster) {
+		m.queueSize = size
+		m.overflow = overflow
+	}
+}
+
+// eventMulticaster is the default definition.ApplicationEventMulticaster
+type eventMulticaster struct {
+	policy       ErrorPolicy
+	errorHandler EventErrorHandlerFunc
+	workers      int
+	queueSize    int
+	overflow     OverflowPolicy
+	queue        chan delivery
+	start        sync.Once
+	// mu guards closed, the deliveries are queued under its read lock
+	mu      sync.RWMutex
+	closed  bool
+	running sync.WaitGroup
+}
+
+// delivery is an event to deliver to a listener by an asynchronous multicaster
+type delivery struct {
+	event    definition.ApplicationEvent
+	listener definition.ApplicationEventListener
+}
+
+// NewEventMulticaster returns a multicaster to register as a component, synchronous and StopOnError by default
+func NewEventMulticaster(opts ...MulticasterOption) definition.ApplicationEventMulticaster {
+	m := &eventMulticaster{queueSize: defaultQueueSize}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.workers > 0 {
+		m.queue = make(chan delivery, max(m.queueSize, 0))
+	}
+	return m
+}
+
+func (m *eventMulticaster) MulticastEvent(event definition.ApplicationEvent, listeners []definition.ApplicationEventListener) error {
+	if m.queue != nil {
+		m.start.Do(m.startWorkers)
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		for _, l := range listeners {
+			m.schedule(delivery{event: event, listener: l})
+		}
+		return nil
+	}
+	var errs []error
+	for _, l := range listeners {
+		if err := m.invoke(event, l); err != nil {
+			if m.policy == StopOnError {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// startWorkers starts the workers delivering the queued events until the multicaster is closed
+func (m *eventMulticaster) startWorkers() {
+	for i := 0; i < m.workers; i++ {
+		m.running.Add(1)
+		go func() {
+			defer m.running.Done()
+			for d := range m.queue {
+				m.deliver(d)
+			}
+		}()
+	}
+}
+
+// schedule queues d by the overflow policy, it is delivered synchronously once the multicaster is closed
+func (m *eventMulticaster) schedule(d delivery) {
+	if m.closed {
+		m.deliver(d)
+		return
+	}
+	switch m.overflow {
+	case DropOnOverflow:
+		select {
+		case m.queue <- d:
+		default:
+			m.logger().Warnf("event queue is full, drop event %T for listener %s", d.event, describeListener(d.listener))
+		}
+	case CallerRunsOnOverflow:
+		select {
+		case m.queue <- d:
+		default:
+			m.deliver(d)
+		}
+	default:
+		m.queue <- d
+	}
+}
+
+func (m *eventMulticaster) deliver(d delivery) {
+	if err := m.invoke(d.event, d.listener); err != nil {
+		m.logger().Errorf("%+v", err)
+	}
+}
+
+// invoke calls listener and its error handlers, a panic of the listener is returned as an error
+func (m *eventMulticaster) invoke(event definition.ApplicationEvent, listener definition.ApplicationEventListener) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("event listener %s panicked: %v", describeListener(listener), r)
+		}
+	}()
+	err = listener.OnEvent(event)
+	if err == nil {
+		return nil
+	}
+	if h, ok := listener.(definition.EventErrorHandler); ok {
+		err = h.HandleEventError(event, err)
+	}
+	if err != nil && m.errorHandler != nil {
+		err = m.errorHandler(event, listenerOf(listener), err)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "event listener %s failed", describeListener(listener))
+	}
+	return nil
+}
+
+// Close stops the workers once they delivered the queued events, the events published afterwards are delivered synchronously
+func (m *eventMulticaster) Close() error {
+	m.mu.Lock()
+	if m.queue != nil && !m.closed {
+		close(m.queue)
+	}
+	m.closed = true
+	m.mu.Unlock()
+	m.running.Wait()
+	return nil
+}
+
+func (m *eventMulticaster) logger() syslog.Logger {
+	return syslog.Pref("EventMulticaster")
+}
+
+// listenerOf returns the listening component or function of a listener
+func listenerOf(listener definition.ApplicationEventListener) any {
+	if l, ok := listener.(*eventListener); ok {
+		return l.listener
+	}
+	return listener
+}
+
+func describeListener(listener definition.ApplicationEventListener) string {
+	return fmt.Sprintf("%T", listenerOf(listener))
+}
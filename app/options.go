@@ -1,10 +1,13 @@
 package app
 
 import (
+	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/configure"
 	"github.com/go-kid/ioc/configure/loader"
 	"github.com/go-kid/ioc/container"
 	"github.com/go-kid/ioc/syslog"
+	"github.com/pkg/errors"
+	"reflect"
 	"time"
 )
 
@@ -18,6 +21,20 @@ func Options(ops ...SettingOption) SettingOption {
 	}
 }
 
+// failOption records the error of an option the registry or the factory does not support, initiate returns it
+func (s *App) failOption(err error) {
+	s.optionErrors = append(s.optionErrors, err)
+}
+
+// overrideRegistry returns the registry of s if it supports overriding components, or fails the option
+func (s *App) overrideRegistry() (container.OverrideRegistry, bool) {
+	registry, ok := s.registry.(container.OverrideRegistry)
+	if !ok {
+		s.failOption(errors.Errorf("registry %T does not support overriding components", s.registry))
+	}
+	return registry, ok
+}
+
 func SetRegistry(r container.SingletonRegistry) SettingOption {
 	return func(s *App) {
 		s.registry = r
@@ -32,6 +49,71 @@ func SetComponents(cs ...any) SettingOption {
 	}
 }
 
+// SetNamedComponent registers a pre-built value under name, for types which can not implement definition.NamingComponent.
+// Options declare the attributes such types can not implement either: As, AsPrimary and WithQualifier.
+func SetNamedComponent(name string, component any, opts ...component_definition.RegistrationOption) SettingOption {
+	return func(s *App) {
+		if _, ok := s.registry.(container.RegistrationRegistry); !ok {
+			s.failOption(errors.Errorf("registry %T does not support named registrations", s.registry))
+			return
+		}
+		s.registry.RegisterSingleton(component_definition.NewRegistration(name, component, opts...))
+	}
+}
+
+// As exposes the component as interface T only, it is no more injected by its concrete type
+func As[T any]() component_definition.RegistrationOption {
+	return func(r *component_definition.Registration) {
+		r.Type = reflect.TypeOf((*T)(nil)).Elem()
+	}
+}
+
+// AsPrimary declares the component primary like definition.WirePrimary
+func AsPrimary() component_definition.RegistrationOption {
+	return func(r *component_definition.Registration) {
+		r.Primary = true
+	}
+}
+
+// WithQualifier declares the qualifier of the component like definition.WireQualifier
+func WithQualifier(qualifier string) component_definition.RegistrationOption {
+	return func(r *component_definition.Registration) {
+		r.Qualifier = qualifier
+	}
+}
+
+// SetOverridePolicy sets how components registered with a name already in use are handled, default is container.OverridePanic.
+// It applies to the registrations after it, so put it before the options registering components.
+func SetOverridePolicy(policy container.OverridePolicy) SettingOption {
+	return func(s *App) {
+		if registry, ok := s.overrideRegistry(); ok {
+			registry.SetOverridePolicy(policy)
+		}
+	}
+}
+
+// ReplaceComponent registers replacement under the name of old, whether old is registered before or after it.
+// old is a component, a constructor or a component name, e.g. replace a default implementation by a stub in tests:
+//
+//	app.ReplaceComponent(&SmtpMailer{}, &StubMailer{})
+func ReplaceComponent(old, replacement any) SettingOption {
+	return func(s *App) {
+		if registry, ok := s.overrideRegistry(); ok {
+			registry.ReplaceSingleton(old, replacement)
+		}
+	}
+}
+
+// SetScope registers a custom scope by name, components declare it by definition.ScopeComponent
+func SetScope(name string, scope container.Scope) SettingOption {
+	return func(s *App) {
+		if s.scopes == nil {
+			s.scopes = make(map[string]container.Scope)
+		}
+		s.scopes[name] = scope
+	}
+}
+
 func SetConfigure(c configure.Configure) SettingOption {
 	return func(s *App) {
 		s.Configure = c
@@ -92,6 +174,47 @@ func SkipRunners() SettingOption {
 	}
 }
 
+// StrictWiring makes single-valued injection (wire fields and constructor parameters) fail with a report
+// of all candidates when more than one remains after Qualifier and WirePrimary filtering, instead of choosing one.
+func StrictWiring() SettingOption {
+	return func(s *App) {
+		s.strictWiring = true
+	}
+}
+
+// ParallelRefresh creates the singletons concurrently with at most workers goroutines during refresh,
+// a component is created once the components it depends on are created, circular references are created by one worker.
+// Post processors and Init methods of the components must be safe for concurrent use, workers <= 1 disables it.
+func ParallelRefresh(workers int) SettingOption {
+	return func(s *App) {
+		s.refreshWorkers = workers
+	}
+}
+
+// AggregateErrors makes refresh continue past failed components and skip the components depending on them,
+// the refresh then fails with a *container.RefreshError reporting all of them instead of the first error.
+func AggregateErrors() SettingOption {
+	return func(s *App) {
+		s.aggregateErrors = true
+	}
+}
+
+// DisallowCircularReferences makes any circular reference between components fail the refresh
+// with a *container.CircularReferenceError, instead of resolving field cycles by early references.
+func DisallowCircularReferences() SettingOption {
+	return func(s *App) {
+		s.disallowCircular = true
+	}
+}
+
+// MeasureStartup makes the factory measure the time spent creating each component and in each ComponentPostProcessor,
+// reported by App.StartupReport. It is disabled by default to keep the timing calls out of the creation path.
+func MeasureStartup() SettingOption {
+	return func(s *App) {
+		s.measureStartup = true
+	}
+}
+
 var (
 	LogTrace = LogLevel(syslog.LvTrace)
 	LogDebug = LogLevel(syslog.LvDebug)
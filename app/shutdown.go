@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/definition"
+	"github.com/pkg/errors"
+)
+
+// closerNode is a closer component in the shutdown graph.
+// A closer is closed only after all of its dependents have been closed.
+type closerNode struct {
+	name       string
+	closer     definition.CloserComponent
+	deps       []*closerNode
+	dependents []*closerNode
+	done       chan struct{}
+}
+
+type componentKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+func newComponentKey(v reflect.Value) (componentKey, bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return componentKey{typ: v.Type(), ptr: v.Pointer()}, true
+	default:
+		return componentKey{}, false
+	}
+}
+
+// planShutdown builds the closer dependency graph from the created singletons.
+// Dependencies are collected from the injected properties and the dependency trackers, closers reached
+// through non-closer components are connected transitively.
+// Circular dependencies are broken by walking the closers in name order, the dropped cycles are returned.
+func (s *App) planShutdown() ([]*closerNode, [][]string) {
+	singletons := s.singletons()
+	var (
+		metaByKey = make(map[componentKey]*component_definition.Meta, len(singletons))
+		dependsOn = make(map[string][]string)
+	)
+	addEdge := func(from, to string) {
+		if from != to && !slices.Contains(dependsOn[from], to) {
+			dependsOn[from] = append(dependsOn[from], to)
+		}
+	}
+	for _, meta := range singletons {
+		if key, ok := newComponentKey(meta.Value); ok {
+			metaByKey[key] = meta
+		}
+		for _, prop := range meta.GetComponentProperties() {
+			for _, inject := range prop.Injects {
+				if inject != nil {
+					addEdge(meta.Name(), inject.Name())
+				}
+			}
+		}
+		for _, dependent := range meta.GetDependents() {
+			addEdge(dependent, meta.Name())
+		}
+	}
+
+	var (
+		nodes  = make([]*closerNode, 0, len(s.CloserComponents))
+		byName = make(map[string]*closerNode, len(s.CloserComponents))
+	)
+	for _, closer := range s.CloserComponents {
+		node := &closerNode{
+			closer: closer,
+			done:   make(chan struct{}),
+		}
+		if key, ok := newComponentKey(reflect.ValueOf(closer)); ok {
+			if meta, found := metaByKey[key]; found && byName[meta.Name()] == nil {
+				node.name = meta.Name()
+				byName[node.name] = node
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	slices.SortStableFunc(nodes, compareCloserNode)
+
+	for _, node := range nodes {
+		if node.name == "" {
+			continue
+		}
+		visited := map[string]bool{node.name: true}
+		var walk func(name string)
+		walk = func(name string) {
+			for _, dep := range dependsOn[name] {
+				if visited[dep] {
+					continue
+				}
+				visited[dep] = true
+				if depNode, ok := byName[dep]; ok {
+					node.deps = append(node.deps, depNode)
+					continue
+				}
+				walk(dep)
+			}
+		}
+		walk(node.name)
+		slices.SortStableFunc(node.deps, compareCloserNode)
+	}
+
+	cycles := breakCloserCycles(nodes)
+	for _, node := range nodes {
+		for _, dep := range node.deps {
+			dep.dependents = append(dep.dependents, node)
+		}
+	}
+	return nodes, cycles
+}
+
+func compareCloserNode(a, b *closerNode) int {
+	return strings.Compare(a.name, b.name)
+}
+
+// breakCloserCycles removes the back edges found by a depth-first walk in the given node order and returns the cycle paths
+func breakCloserCycles(nodes []*closerNode) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		states = make(map[*closerNode]int, len(nodes))
+		stack  []*closerNode
+		cycles [][]string
+		visit  func(n *closerNode)
+	)
+	visit = func(n *closerNode) {
+		states[n] = visiting
+		stack = append(stack, n)
+		deps := n.deps[:0:0]
+		for _, dep := range n.deps {
+			switch states[dep] {
+			case visiting:
+				start := slices.Index(stack, dep)
+				var path []string
+				for _, c := range stack[start:] {
+					path = append(path, c.name)
+				}
+				cycles = append(cycles, append(path, dep.name))
+				continue
+			case unvisited:
+				visit(dep)
+			}
+			deps = append(deps, dep)
+		}
+		n.deps = deps
+		stack = stack[:len(stack)-1]
+		states[n] = visited
+	}
+	for _, n := range nodes {
+		if states[n] == unvisited {
+			visit(n)
+		}
+	}
+	return cycles
+}
+
+// closeComponents closes dependents before their dependencies, independent closers are closed concurrently
+func (s *App) closeComponents(ctx context.Context) {
+	nodes, cycles := s.planShutdown()
+	for _, cycle := range cycles {
+		s.logger().Warnf("closer components have circular dependency %s, fall back to name order", strings.Join(cycle, " -> "))
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(len(nodes))
+	for _, n := range nodes {
+		go func(n *closerNode) {
+			defer wg.Done()
+			defer close(n.done)
+			for _, dependent := range n.dependents {
+				select {
+				case <-dependent.done:
+				case <-ctx.Done():
+				}
+			}
+			start := time.Now()
+			var err error
+			if c, ok := n.closer.(definition.CloserComponentWithContext); ok {
+				err = c.CloseWithContext(ctx)
+			} else {
+				err = n.closer.Close()
+			}
+			s.publishLifecycleEvent(&definition.CloserFinishedEvent{App: s, Closer: n.closer, Err: err, Elapsed: time.Since(start)})
+			if err != nil {
+				err = errors.Wrapf(err, "invoking Close() for closer '%T'", n.closer)
+				s.logger().Errorf("%+v", err)
+			}
+		}(n)
+	}
+	wg.Wait()
+}
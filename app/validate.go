@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+
+	"github.com/go-kid/ioc/container"
+	"github.com/pkg/errors"
+)
+
+// Validate prepares the components and checks their wiring without creating them, calling init methods or runners.
+// The wiring is checked in the mode the application runs in, ambiguous candidates are only reported with StrictWiring.
+// All the problems found are returned at once as *container.ValidationError.
+func (s *App) Validate(ops ...SettingOption) error {
+	return s.ValidateWithContext(context.Background(), ops...)
+}
+
+func (s *App) ValidateWithContext(ctx context.Context, ops ...SettingOption) error {
+	if err := s.prepare(ctx, ops); err != nil {
+		return err
+	}
+	validator, ok := s.Factory.(container.WiringValidator)
+	if !ok {
+		return errors.Errorf("factory %T does not support wiring validation", s.Factory)
+	}
+	s.logger().Info("start validating component wiring...")
+	if problems := validator.ValidateWiring(); len(problems) != 0 {
+		return &container.ValidationError{Problems: problems}
+	}
+	s.logger().Info("component wiring is valid")
+	return nil
+}
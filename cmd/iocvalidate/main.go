@@ -0,0 +1,33 @@
+// Command iocvalidate checks the component wiring of an application for CI, without starting it.
+//
+// It runs the main package with the --ioc:validate flag handled by ioc.Run, which prepares the components,
+// reports every unresolved wire field, missing configuration, ambiguous candidate (in strict wiring mode) and constructor cycle,
+// and exits with status 1 if any is found.
+//
+//	go run github.com/go-kid/ioc/cmd/iocvalidate ./cmd/server [app args...]
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: iocvalidate <main package> [app args...]")
+		os.Exit(2)
+	}
+	args := append([]string{"run", os.Args[1], "--ioc:validate"}, os.Args[2:]...)
+	cmd := exec.Command("go", args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,173 @@
+package component_definition
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-kid/ioc/definition"
+	"github.com/go-kid/strings2"
+	"github.com/pkg/errors"
+)
+
+// Constructor is a constructor function registered as component, with optional annotations of its parameters
+type Constructor struct {
+	Name   string
+	Fn     any
+	Type   reflect.Type
+	params map[int]*ConstructorParam
+	// duplicates are the indexes annotated more than once, rejected by ValidateParams
+	duplicates []int
+}
+
+func NewConstructor(fn any, params ...*ConstructorParam) *Constructor {
+	c := &Constructor{
+		Fn:     fn,
+		Type:   reflect.TypeOf(fn),
+		params: make(map[int]*ConstructorParam, len(params)),
+	}
+	for _, param := range params {
+		if _, ok := c.params[param.Index]; ok {
+			c.duplicates = append(c.duplicates, param.Index)
+		}
+		c.params[param.Index] = param
+	}
+	return c
+}
+
+// Named registers the produced component with name instead of the name of its type,
+// so several constructors or factory methods can produce components of the same type.
+func (c *Constructor) Named(name string) *Constructor {
+	c.Name = name
+	return c
+}
+
+// Param returns the annotation of the parameter at index, or nil if the parameter is not annotated
+func (c *Constructor) Param(index int) *ConstructorParam {
+	return c.params[index]
+}
+
+// ValidateParams checks the annotated parameters exist in the constructor signature and are annotated once
+func (c *Constructor) ValidateParams() error {
+	if len(c.duplicates) != 0 {
+		return errors.Errorf("constructor %s has parameter[%d] annotated more than once", c.Type, c.duplicates[0])
+	}
+	for index := range c.params {
+		if index < 0 || index >= c.Type.NumIn() {
+			return errors.Errorf("constructor %s has no parameter[%d]", c.Type, index)
+		}
+	}
+	return nil
+}
+
+// ConstructorParam annotates a constructor parameter the way a tag annotates a field,
+// it shares the TagArg semantics of the `wire` tag (name, qualifier, required),
+// or binds the parameter to a configuration value like the `value` tag.
+type ConstructorParam struct {
+	Index  int
+	Name   string
+	Tag    string
+	TagVal string
+	args   TagArg
+}
+
+func NewConstructorParam(index int) *ConstructorParam {
+	return &ConstructorParam{
+		Index: index,
+		args:  make(TagArg),
+	}
+}
+
+// Wire annotates the parameter with a `wire` tag value, e.g. "primaryDB" or ",qualifier=cache,required=false"
+func (p *ConstructorParam) Wire(tagVal string) *ConstructorParam {
+	p.Name = p.args.Parse(tagVal)
+	return p
+}
+
+// Named injects the component with the given name
+func (p *ConstructorParam) Named(name string) *ConstructorParam {
+	p.Name = name
+	return p
+}
+
+// Qualifier injects the component matching one of the qualifiers
+func (p *ConstructorParam) Qualifier(qualifiers ...string) *ConstructorParam {
+	p.args.Set(ArgQualifier, qualifiers...)
+	return p
+}
+
+// Optional injects the zero value instead of failing when no component is found
+func (p *ConstructorParam) Optional() *ConstructorParam {
+	p.args.Set(ArgRequired, "false")
+	return p
+}
+
+// Value binds the parameter to a configuration value with the syntax of the `value` tag,
+// e.g. "${db.timeout:5s}", "#{1+1}" or "${db.dsn},required=false"
+func (p *ConstructorParam) Value(tagVal string) *ConstructorParam {
+	p.Tag = definition.ValueTag
+	p.TagVal = p.args.Parse(tagVal)
+	return p
+}
+
+// Prop binds the parameter to a configuration path, it is the sugar of Value like the `prop` tag
+func (p *ConstructorParam) Prop(path string) *ConstructorParam {
+	var argstr string
+	if i := strings2.IndexSkipBlocks(path, ","); i != -1 {
+		path, argstr = path[:i], path[i:]
+	}
+	return p.Value(fmt.Sprintf("${%s}%s", path, argstr))
+}
+
+// IsConfiguration reports whether the parameter is bound to a configuration value instead of a component
+func (p *ConstructorParam) IsConfiguration() bool {
+	return p != nil && p.Tag == definition.ValueTag
+}
+
+// NewProperty creates the configuration property of the parameter held by holder,
+// the parameter value is resolved through the property pipeline of fields.
+func (p *ConstructorParam) NewProperty(holder *Holder, paramType reflect.Type) *Property {
+	field := &Field{
+		Base: &Base{
+			Type:  paramType,
+			Value: reflect.New(paramType).Elem(),
+		},
+		Holder: holder,
+		StructField: reflect.StructField{
+			Name: fmt.Sprintf("Param%d", p.Index),
+			Type: paramType,
+		},
+	}
+	return &Property{
+		Field:          field,
+		PropertyType:   PropertyTypeConfiguration,
+		Tag:            p.Tag,
+		TagStr:         p.TagVal,
+		TagVal:         p.TagVal,
+		Configurations: make(map[string]any),
+		args:           p.args,
+	}
+}
+
+func (p *ConstructorParam) Args() TagArg {
+	return p.args
+}
+
+// IsSelective reports whether the parameter narrows the candidates by name or qualifier
+func (p *ConstructorParam) IsSelective() bool {
+	if p == nil {
+		return false
+	}
+	_, qualified := p.args.Find(ArgQualifier)
+	return p.Name != "" || qualified
+}
+
+func (p *ConstructorParam) IsRequired() bool {
+	return p == nil || !p.args.Has(ArgRequired, "false")
+}
+
+func (p *ConstructorParam) String() string {
+	if p.IsConfiguration() {
+		return fmt.Sprintf("Param(%d).Tag(%s:'%s')%s", p.Index, p.Tag, p.TagVal, p.args.String())
+	}
+	return fmt.Sprintf("Param(%d).Name(%s)%s", p.Index, p.Name, p.args.String())
+}
@@ -1,28 +1,37 @@
 package component_definition
 
 import (
+	"sync"
+
 	"github.com/go-kid/ioc/util/sync2"
 	"github.com/samber/lo"
 )
 
 type DependencyTracker struct {
+	mu           *sync.RWMutex
 	dependentSet *sync2.Map[string, struct{}]
 	Dependent    []*Meta
 }
 
 func newDependencyTracker() DependencyTracker {
 	return DependencyTracker{
+		mu:           &sync.RWMutex{},
 		dependentSet: sync2.New[string, struct{}](),
 	}
 }
 
-func (dt *DependencyTracker) dependOn(dependent *Meta) {
+// DependOn records that dependent has the tracked component injected
+func (dt *DependencyTracker) DependOn(dependent *Meta) {
 	_, loaded := dt.dependentSet.LoadOrStore(dependent.ID(), struct{}{})
 	if !loaded {
+		dt.mu.Lock()
 		dt.Dependent = append(dt.Dependent, dependent)
+		dt.mu.Unlock()
 	}
 }
 
 func (dt *DependencyTracker) GetDependents() []string {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
 	return lo.Map(dt.Dependent, func(m *Meta, _ int) string { return m.Name() })
 }
@@ -0,0 +1,158 @@
+package component_definition
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// InjectionPoint locates the field or the constructor parameter a resolution error happened on
+type InjectionPoint struct {
+	// Component is the name of the component being injected
+	Component string
+	// Field is the field name or the constructor parameter (e.g. "param[0]"), empty if unknown
+	Field string
+	// Chain is the chain of components being resolved, from the refreshed component to Component,
+	// it is filled by the factory
+	Chain []string
+}
+
+// Point returns the injection point, so that InjectionError is implemented by every error embedding it
+func (p *InjectionPoint) Point() *InjectionPoint {
+	return p
+}
+
+func (p *InjectionPoint) String() string {
+	var s string
+	switch {
+	case p.Field == "":
+		s = fmt.Sprintf("component '%s'", p.Component)
+	case strings.HasPrefix(p.Field, "param["):
+		s = fmt.Sprintf("constructor %s of component '%s'", p.Field, p.Component)
+	default:
+		s = fmt.Sprintf("field '%s' of component '%s'", p.Field, p.Component)
+	}
+	if len(p.Chain) > 1 {
+		s += fmt.Sprintf(" (dependency chain: %s)", strings.Join(p.Chain, " -> "))
+	}
+	return s
+}
+
+// InjectionError is implemented by the resolution errors of the factory
+type InjectionError interface {
+	error
+	Point() *InjectionPoint
+}
+
+// PointOf returns the injection point of a property
+func PointOf(prop *Property) InjectionPoint {
+	return InjectionPoint{Component: prop.Holder.Meta.Name(), Field: prop.StructField.Name}
+}
+
+// NoSuchComponentError reports a required field or constructor parameter without any candidate component
+type NoSuchComponentError struct {
+	InjectionPoint
+	Type reflect.Type
+	// Qualifiers are the qualifiers all the candidates were filtered out by
+	Qualifiers []string
+	// Reason tells why candidates were filtered out, empty if there was none
+	Reason string
+}
+
+func (e *NoSuchComponentError) Error() string {
+	s := fmt.Sprintf("no component found for type %s", e.Type)
+	if len(e.Qualifiers) != 0 {
+		s += fmt.Sprintf(" matching qualifier '%s'", strings.Join(e.Qualifiers, ","))
+	}
+	if e.Reason != "" {
+		s += " " + e.Reason
+	}
+	if e.Component == "" {
+		return s
+	}
+	return fmt.Sprintf("%s: %s", &e.InjectionPoint, s)
+}
+
+// AmbiguousComponentError reports a single-valued field or constructor parameter with several candidates
+// and no unique primary one
+type AmbiguousComponentError struct {
+	InjectionPoint
+	Type reflect.Type
+	// Candidates are the sorted names of the candidates, the primary ones if there are several
+	Candidates []string
+	// Primary reports whether Candidates are all primary
+	Primary bool
+}
+
+func (e *AmbiguousComponentError) Error() string {
+	kind := "candidates"
+	if e.Primary {
+		kind = "primary candidates"
+	}
+	s := fmt.Sprintf("%d %s found: [%s]", len(e.Candidates), kind, strings.Join(e.Candidates, ", "))
+	if e.Component == "" {
+		return s
+	}
+	return fmt.Sprintf("%s is ambiguous for type %s: %s", &e.InjectionPoint, e.Type, s)
+}
+
+// CircularReference is the path of a circular reference between components
+type CircularReference struct {
+	// Cycle is the path of the circular reference, starting and ending with the same component, empty if unknown
+	Cycle []string
+	// Via are the fields or the constructor parameters of Cycle[i] injected with Cycle[i+1]
+	Via []string
+}
+
+// Path formats the cycle with the injection sites, e.g. "A (param[0]) -> B (Repo) -> A"
+func (r CircularReference) Path() string {
+	var sb strings.Builder
+	for i, name := range r.Cycle {
+		if i > 0 {
+			sb.WriteString(" -> ")
+		}
+		sb.WriteString(name)
+		if i < len(r.Via) && r.Via[i] != "" {
+			fmt.Fprintf(&sb, " (%s)", r.Via[i])
+		}
+	}
+	return sb.String()
+}
+
+// CircularReferenceError reports a circular reference which can not be resolved by an early reference
+type CircularReferenceError struct {
+	InjectionPoint
+	CircularReference
+}
+
+func (e *CircularReferenceError) Error() string {
+	if len(e.Cycle) == 0 {
+		return fmt.Sprintf("circular reference can not be resolved on %s", &e.InjectionPoint)
+	}
+	return fmt.Sprintf("circular reference can not be resolved: %s", e.Path())
+}
+
+// MissingConfigError reports a required configuration value whose key is neither configured nor defaulted
+type MissingConfigError struct {
+	InjectionPoint
+	// Keys are the sorted missing configuration keys
+	Keys []string
+}
+
+func (e *MissingConfigError) Error() string {
+	return fmt.Sprintf("configuration %v required by %s is missing", e.Keys, &e.InjectionPoint)
+}
+
+// ConstructorError reports a constructor returning an error
+type ConstructorError struct {
+	InjectionPoint
+	Err error
+}
+
+func (e *ConstructorError) Error() string {
+	return fmt.Sprintf("constructor of %s returned error: %v", &e.InjectionPoint, e.Err)
+}
+
+func (e *ConstructorError) Unwrap() error {
+	return e.Err
+}
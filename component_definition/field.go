@@ -18,3 +18,16 @@ func (f *Field) ID() string {
 func (f *Field) String() string {
 	return fmt.Sprintf("%s.Field(%s)", f.Holder.String(), f.StructField.Name)
 }
+
+// Detached returns a copy of the field holding a zero value of its own,
+// so that the copy can be resolved without writing into the component.
+func (f *Field) Detached() *Field {
+	return &Field{
+		Base: &Base{
+			Type:  f.Type,
+			Value: reflect.New(f.Type).Elem(),
+		},
+		Holder:      f.Holder,
+		StructField: f.StructField,
+	}
+}
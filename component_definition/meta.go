@@ -23,6 +23,7 @@ type Meta struct {
 	ProxyMeta *Meta
 	name      string
 	alias     string
+	declared  declaration
 
 	Raw    interface{}
 	Fields []*Field
@@ -83,6 +84,7 @@ func (m *Meta) UseProxy(origin any) {
 		ProxyMeta:         nil,
 		name:              m.name,
 		alias:             m.alias,
+		declared:          m.declared,
 		Raw:               m.Raw,
 		Fields:            m.Fields,
 	}
@@ -95,6 +97,7 @@ type Interceptor = func(name string, m *Meta) error
 func CreateProxy(origin *Meta, name string, newComponent any, interceptors ...Interceptor) (*Meta, error) {
 	nm := NewMeta(newComponent)
 	nm.SetName(name)
+	nm.declared = origin.declared
 	nm.ProxyMeta = origin
 	for _, interceptor := range interceptors {
 		err := interceptor(name, nm)
@@ -129,16 +132,67 @@ func (m *Meta) scanFields(holder *Holder) {
 	})
 }
 
-func (m *Meta) IsSingleton() bool {
-	if sc, ok := m.Raw.(definition.ScopeComponent); ok {
-		return sc.Scope() != definition.ScopePrototype
+// declaration holds the attributes of a component declared by its Registration
+type declaration struct {
+	typ       reflect.Type
+	primary   bool
+	qualifier string
+}
+
+// Declare applies the attributes of the registration the component was registered by
+func (m *Meta) Declare(r *Registration) {
+	m.declared = declaration{
+		typ:       r.Type,
+		primary:   r.Primary,
+		qualifier: r.Qualifier,
 	}
-	return true
 }
 
-func (m *Meta) IsPrototype() bool {
-	if sc, ok := m.Raw.(definition.ScopeComponent); ok {
-		return sc.Scope() == definition.ScopePrototype
+// ExposedType returns the type the component is looked up by, it is the declared type of its Registration if any
+func (m *Meta) ExposedType() reflect.Type {
+	if m.declared.typ != nil {
+		return m.declared.typ
+	}
+	return m.Value.Type()
+}
+
+// IsPrimary reports whether the component is declared primary or implements definition.WirePrimary
+func (m *Meta) IsPrimary() bool {
+	if m.declared.primary {
+		return true
+	}
+	_, ok := m.Raw.(definition.WirePrimary)
+	return ok
+}
+
+// Qualifier returns the qualifier declared by the Registration or definition.WireQualifier
+func (m *Meta) Qualifier() (string, bool) {
+	if m.declared.qualifier != "" {
+		return m.declared.qualifier, true
+	}
+	if q, ok := m.Raw.(definition.WireQualifier); ok {
+		return q.Qualifier(), true
 	}
-	return false
+	return "", false
+}
+
+// Scope returns the scope name declared by definition.ScopeComponent, default is definition.ScopeSingleton
+func (m *Meta) Scope() string {
+	if sc, ok := m.Raw.(definition.ScopeComponent); ok && sc.Scope() != "" {
+		return sc.Scope()
+	}
+	return definition.ScopeSingleton
+}
+
+func (m *Meta) IsSingleton() bool {
+	return m.Scope() == definition.ScopeSingleton
+}
+
+func (m *Meta) IsPrototype() bool {
+	return m.Scope() == definition.ScopePrototype
+}
+
+// IsCustomScope reports whether the component lives in a scope registered on the factory
+func (m *Meta) IsCustomScope() bool {
+	return !m.IsSingleton() && !m.IsPrototype()
 }
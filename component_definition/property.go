@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	"reflect"
+	"slices"
 )
 
 type Property struct {
@@ -56,7 +57,7 @@ func (n *Property) Inject(metas []*Meta) error {
 	isRequired := n.IsRequired()
 	if len(metas) == 0 {
 		if isRequired {
-			return errors.Errorf("inject '%s': not found available components", n)
+			return &NoSuchComponentError{InjectionPoint: PointOf(n), Type: n.Type}
 		}
 		return nil
 	}
@@ -67,7 +68,7 @@ func (n *Property) Inject(metas []*Meta) error {
 	})
 	if len(metas) == 0 {
 		if isRequired {
-			return errors.Errorf("inject '%s':%s: self inject not allowed", n, n.Holder.Stack())
+			return &NoSuchComponentError{InjectionPoint: PointOf(n), Type: n.Type, Reason: "except itself, self inject not allowed"}
 		}
 		return nil
 	}
@@ -77,12 +78,12 @@ func (n *Property) Inject(metas []*Meta) error {
 		n.Value.Set(reflect.MakeSlice(n.Type, len(metas), len(metas)))
 		for i, m := range metas {
 			n.Value.Index(i).Set(m.Value)
-			m.dependOn(n.Holder.Meta)
+			m.DependOn(n.Holder.Meta)
 		}
 	default:
 		m := metas[0]
 		n.Value.Set(m.Value)
-		m.dependOn(n.Holder.Meta)
+		m.DependOn(n.Holder.Meta)
 	}
 
 	n.Injects = metas
@@ -102,6 +103,18 @@ func (n *Property) SetConfiguration(path string, configValue any) {
 	n.Configurations[path] = configValue
 }
 
+// MissingConfigurations returns the sorted configuration keys the property refers to that resolved to nothing
+func (n *Property) MissingConfigurations() []string {
+	var keys []string
+	for key, value := range n.Configurations {
+		if value == nil {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
 func (n *Property) Unmarshall(configValue any) error {
 	if n.PropertyType != PropertyTypeConfiguration {
 		return errors.Errorf("property '%s' is not allowed to unmarshall configuration value", n)
@@ -0,0 +1,45 @@
+package component_definition
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Registration is a pre-built component registered under an explicit name,
+// with the attributes a component declares by implementing definition interfaces
+// (definition.WirePrimary, definition.WireQualifier), for types which can not implement them.
+type Registration struct {
+	Name      string
+	Component any
+	Type      reflect.Type
+	Primary   bool
+	Qualifier string
+}
+
+type RegistrationOption func(r *Registration)
+
+func NewRegistration(name string, component any, opts ...RegistrationOption) *Registration {
+	r := &Registration{
+		Name:      name,
+		Component: component,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Validate checks the registration is named and the component is assignable to its exposed type
+func (r *Registration) Validate() error {
+	if r.Name == "" {
+		return errors.New("registration name is empty")
+	}
+	if r.Component == nil {
+		return errors.Errorf("registration '%s' has nil component", r.Name)
+	}
+	if r.Type != nil && !reflect.TypeOf(r.Component).AssignableTo(r.Type) {
+		return errors.Errorf("registration '%s': %T is not assignable to %s", r.Name, r.Component, r.Type)
+	}
+	return nil
+}
@@ -1,11 +1,12 @@
 package component_definition
 
 import (
-	"github.com/go-kid/ioc/definition"
-	"github.com/go-kid/ioc/util/reflectx"
-)
+	"slices"
+	"strings"
 
-var wirePrimaryInterface = new(definition.WirePrimary)
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
 
 // SelectBestCandidate picks the best match from multiple Meta candidates
 // using the priority: WirePrimary > non-alias > first element.
@@ -15,7 +16,7 @@ func SelectBestCandidate(metas []*Meta) *Meta {
 	}
 	candidate := metas[0]
 	for _, m := range metas {
-		if reflectx.IsTypeImplement(m.Type, wirePrimaryInterface) {
+		if m.IsPrimary() {
 			return m
 		}
 		if !m.IsAlias() {
@@ -24,3 +25,35 @@ func SelectBestCandidate(metas []*Meta) *Meta {
 	}
 	return candidate
 }
+
+// SelectUniqueCandidate is the strict version of SelectBestCandidate,
+// it only narrows multiple candidates down to the single WirePrimary one and reports an *AmbiguousComponentError listing all candidates otherwise.
+func SelectUniqueCandidate(metas []*Meta) (*Meta, error) {
+	switch len(metas) {
+	case 0:
+		return nil, errors.New("no candidate found")
+	case 1:
+		return metas[0], nil
+	}
+	primaries := lo.Filter(metas, func(m *Meta, _ int) bool {
+		return m.IsPrimary()
+	})
+	if len(primaries) == 1 {
+		return primaries[0], nil
+	}
+	if len(primaries) > 1 {
+		return nil, &AmbiguousComponentError{Candidates: sortedNames(primaries), Primary: true}
+	}
+	return nil, &AmbiguousComponentError{Candidates: sortedNames(metas)}
+}
+
+func sortedNames(metas []*Meta) []string {
+	names := lo.Map(metas, func(m *Meta, _ int) string { return m.Name() })
+	slices.Sort(names)
+	return names
+}
+
+// CandidateNames formats the sorted names of candidates
+func CandidateNames(metas []*Meta) string {
+	return "[" + strings.Join(sortedNames(metas), ", ") + "]"
+}
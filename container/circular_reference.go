@@ -0,0 +1,12 @@
+package container
+
+import "github.com/go-kid/ioc/component_definition"
+
+// CircularReference is the path of a circular reference between components
+type CircularReference = component_definition.CircularReference
+
+// CircularReferenceReporter is implemented by factories reporting the circular references
+// they resolved by early references during refresh.
+type CircularReferenceReporter interface {
+	GetCircularReferences() []CircularReference
+}
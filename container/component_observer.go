@@ -0,0 +1,17 @@
+package container
+
+import (
+	"time"
+
+	"github.com/go-kid/ioc/component_definition"
+)
+
+// ComponentObserver is notified of the component creations by the factories supporting it,
+// elapsed is the time since the creation of the component started, including the creation of its dependencies.
+// It may be called concurrently when the factory refreshes in parallel.
+type ComponentObserver interface {
+	// ComponentCreated is called once the component is instantiated, before its properties are injected
+	ComponentCreated(name string, meta *component_definition.Meta, elapsed time.Duration)
+	// ComponentInitialized is called once the component is injected and initialized by the ComponentPostProcessor
+	ComponentInitialized(name string, meta *component_definition.Meta, elapsed time.Duration)
+}
@@ -1,6 +1,8 @@
 package container
 
 import (
+	"context"
+
 	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/configure"
 )
@@ -26,6 +28,26 @@ type SingletonRegistry interface {
 	GetConstructor(name string) (any, bool)
 }
 
+// OverrideRegistry is implemented by singleton registries resolving the registrations of a name already in use by an OverridePolicy
+type OverrideRegistry interface {
+	SetOverridePolicy(policy OverridePolicy)
+	// ReplaceSingleton registers replacement under the name old is (or will be) registered by, old is a component or its name
+	ReplaceSingleton(old, replacement any)
+	GetOverrides() []ComponentOverride
+}
+
+// ConstructorRegistry is implemented by singleton registries keeping the constructors with their parameter annotations,
+// the constructors of the other registries are invoked without annotations.
+type ConstructorRegistry interface {
+	GetConstructorDefinition(name string) (*component_definition.Constructor, bool)
+}
+
+// RegistrationRegistry is implemented by singleton registries accepting *component_definition.Registration,
+// the values registered under explicit names with their declared attributes.
+type RegistrationRegistry interface {
+	GetRegistration(name string) (*component_definition.Registration, bool)
+}
+
 type DefinitionRegistry interface {
 	RegisterMeta(m *component_definition.Meta)
 	GetMetas(opts ...Option) []*component_definition.Meta
@@ -42,6 +64,12 @@ type SingletonComponentRegistry interface {
 	IsSingletonCurrentlyInCreation(name string) bool
 }
 
+// SingletonNameLister is implemented by singleton component registries listing the singletons they hold
+type SingletonNameLister interface {
+	// GetSingletonNames returns the names of the fully created singletons in creation order
+	GetSingletonNames() []string
+}
+
 type SingletonFactory interface {
 	GetComponent() (*component_definition.Meta, error)
 }
@@ -52,6 +80,17 @@ func (d FuncSingletonFactory) GetComponent() (*component_definition.Meta, error)
 	return d()
 }
 
+// Scope is a strategy for component instances living shorter than singletons, registered on the Factory by scope name.
+// Components declare their scope by implementing definition.ScopeComponent.
+type Scope interface {
+	// Get returns the instance with the given name from the scope bound to ctx, and create it by factory if absent
+	Get(ctx context.Context, name string, factory SingletonFactory) (*component_definition.Meta, error)
+	// Remove removes the instance with the given name from the scope bound to ctx
+	Remove(ctx context.Context, name string) (*component_definition.Meta, bool)
+	// RegisterDestructionCallback registers a callback to be executed when the instance with the given name is destroyed by the scope
+	RegisterDestructionCallback(ctx context.Context, name string, callback func())
+}
+
 type Factory interface {
 	GetRegisteredComponents() map[string]any
 	GetDefinitionRegistryPostProcessors() []DefinitionRegistryPostProcessor
@@ -65,6 +104,25 @@ type Factory interface {
 	GetDefinitionRegistry() DefinitionRegistry
 }
 
+// ScopedFactory is implemented by factories supporting custom scopes, registered by name
+type ScopedFactory interface {
+	// GetComponentByNameWithContext is like GetComponentByName, but resolves custom scoped components against ctx
+	GetComponentByNameWithContext(ctx context.Context, name string) (any, error)
+	RegisterScope(name string, scope Scope)
+	GetRegisteredScope(name string) (Scope, bool)
+}
+
+// SingletonLister is implemented by factories listing the singletons they created
+type SingletonLister interface {
+	// GetSingletons returns the metas of the fully created singletons in creation order
+	GetSingletons() []*component_definition.Meta
+}
+
+// SingletonDestroyer is implemented by factories destroying the created singletons when the application is closed
+type SingletonDestroyer interface {
+	DestroySingletons() error
+}
+
 // ComponentFactoryPostProcessor Used for Component to get Factory
 type ComponentFactoryPostProcessor interface {
 	PostProcessComponentFactory(factory Factory) error
@@ -107,8 +165,12 @@ type SmartInstantiationAwareBeanPostProcessor interface {
 	GetEarlyBeanReference(component any, componentName string) (any, error)
 }
 
+// DestructionAwareComponentPostProcessor is an extension of ComponentPostProcessor
+// that is called for every created singleton when the application is closing, before CloserComponent.Close
 type DestructionAwareComponentPostProcessor interface {
 	ComponentPostProcessor
+	// PostProcessBeforeDestruction called before the component is destroyed
 	PostProcessBeforeDestruction(component any, componentName string) error
+	// RequireDestruction Return false to skip PostProcessBeforeDestruction for certain components.
 	RequireDestruction(component any) bool
 }
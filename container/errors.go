@@ -0,0 +1,15 @@
+package container
+
+import "github.com/go-kid/ioc/component_definition"
+
+// The resolution errors returned by the factory, they are declared with the component definitions
+// so that properties can return them, match them with errors.As.
+type (
+	InjectionPoint          = component_definition.InjectionPoint
+	InjectionError          = component_definition.InjectionError
+	NoSuchComponentError    = component_definition.NoSuchComponentError
+	AmbiguousComponentError = component_definition.AmbiguousComponentError
+	CircularReferenceError  = component_definition.CircularReferenceError
+	MissingConfigError      = component_definition.MissingConfigError
+	ConstructorError        = component_definition.ConstructorError
+)
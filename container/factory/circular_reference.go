@@ -0,0 +1,146 @@
+package factory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-kid/ioc/container"
+)
+
+// SetAllowCircularReferences decides whether singletons are early exposed to resolve circular references
+// between fields, when disallowed any circular reference fails with a *container.CircularReferenceError.
+func (f *defaultFactory) SetAllowCircularReferences(allow bool) {
+	f.allowCircularReferences = allow
+}
+
+type injectionSiteKey struct{}
+
+// withInjectionSite records the field or the constructor parameter the next resolved component is injected into
+func withInjectionSite(ctx context.Context, site string) context.Context {
+	return context.WithValue(ctx, injectionSiteKey{}, site)
+}
+
+func injectionSiteOf(ctx context.Context) string {
+	site, _ := ctx.Value(injectionSiteKey{}).(string)
+	return site
+}
+
+// circularReferenceOf returns the circular reference closed by resolving name from ctx, if name is being resolved already
+func circularReferenceOf(ctx context.Context, name string) (container.CircularReference, bool) {
+	var ref container.CircularReference
+	if !appendCycleSegment(&ref, ctx, name) {
+		return container.CircularReference{}, false
+	}
+	ref.Cycle = append(ref.Cycle, name)
+	return ref, true
+}
+
+// circularReferenceAcross returns the circular reference closed by resolving name from ctx of owner,
+// while name is created by creator waiting for owner directly or through other workers.
+// The cycle is joined from the resolve stacks of the waiting workers, it must be called with creationMu held.
+func (f *defaultFactory) circularReferenceAcross(ctx context.Context, creator, owner *creationOwner, name string) (container.CircularReference, bool) {
+	var ref container.CircularReference
+	from := name
+	for o := creator; o != owner; {
+		wait, ok := f.waiting[o]
+		if !ok || !appendCycleSegment(&ref, wait.ctx, from) {
+			return container.CircularReference{}, false
+		}
+		from, o = wait.name, wait.owner
+	}
+	if !appendCycleSegment(&ref, ctx, from) {
+		return container.CircularReference{}, false
+	}
+	ref.Cycle = append(ref.Cycle, name)
+	return ref, true
+}
+
+// appendCycleSegment appends the components resolved by ctx from the component from on to ref,
+// and the injection site of the component ctx resolves next
+func appendCycleSegment(ref *container.CircularReference, ctx context.Context, from string) bool {
+	var frames []*resolveStack
+	for s, _ := ctx.Value(resolveStackKey{}).(*resolveStack); s != nil; s = s.parent {
+		frames = append(frames, s)
+	}
+	slices.Reverse(frames)
+	start := slices.IndexFunc(frames, func(s *resolveStack) bool { return s.name == from })
+	if start < 0 {
+		return false
+	}
+	for i, s := range frames[start:] {
+		ref.Cycle = append(ref.Cycle, s.name)
+		if i > 0 {
+			ref.Via = append(ref.Via, s.via)
+		}
+	}
+	ref.Via = append(ref.Via, injectionSiteOf(ctx))
+	return true
+}
+
+// checkCircularReference fails if name is being resolved by ctx already and no early reference of it can be injected,
+// it happens for components created by constructor, prototypes and when circular references are disallowed.
+func (f *defaultFactory) checkCircularReference(ctx context.Context, name string) error {
+	ref, ok := circularReferenceOf(ctx, name)
+	if !ok {
+		return nil
+	}
+	stack := resolveStackOf(ctx)
+	f.logger().Debugf("circular reference detected: %s", ref.Path())
+	return &container.CircularReferenceError{
+		InjectionPoint: container.InjectionPoint{
+			Component: stack[len(stack)-1],
+			Field:     injectionSiteOf(ctx),
+			Chain:     stack,
+		},
+		CircularReference: ref,
+	}
+}
+
+// recordCircularReference records the circular reference resolved by the early reference of name
+func (f *defaultFactory) recordCircularReference(ctx context.Context, name string) {
+	if ref, ok := circularReferenceOf(ctx, name); ok {
+		f.addCircularReference(name, ref)
+	}
+}
+
+// addCircularReference records ref, the circular reference resolved by the early reference of name
+func (f *defaultFactory) addCircularReference(name string, ref container.CircularReference) {
+	f.circularMu.Lock()
+	defer f.circularMu.Unlock()
+	f.circularReferences = append(f.circularReferences, ref)
+	// the components of the cycle after name are created holding the early reference of name
+	for _, holder := range ref.Cycle[1 : len(ref.Cycle)-1] {
+		if !slices.Contains(f.earlyHolders[name], holder) {
+			f.earlyHolders[name] = append(f.earlyHolders[name], holder)
+		}
+	}
+}
+
+// dropFailedSingleton drops the partly created singleton name, so that no one gets its early reference
+// and the next lookup creates it again. The singletons holding its early reference are dropped too,
+// they are created again with the next instance instead of keeping the failed one.
+func (f *defaultFactory) dropFailedSingleton(name string) {
+	f.circularMu.Lock()
+	holders := f.earlyHolders[name]
+	delete(f.earlyHolders, name)
+	f.circularMu.Unlock()
+	f.singletonComponentRegistry.RemoveSingleton(name)
+	for _, holder := range holders {
+		f.logger().Debugf("drop singleton '%s' holding the early reference of the failed singleton '%s'", holder, name)
+		f.singletonComponentRegistry.RemoveSingleton(holder)
+	}
+}
+
+// forgetEarlyHolders forgets the holders of the early reference of name once it is created
+func (f *defaultFactory) forgetEarlyHolders(name string) {
+	f.circularMu.Lock()
+	defer f.circularMu.Unlock()
+	delete(f.earlyHolders, name)
+}
+
+// GetCircularReferences returns the circular references resolved by early references, in resolution order
+func (f *defaultFactory) GetCircularReferences() []container.CircularReference {
+	f.circularMu.Lock()
+	defer f.circularMu.Unlock()
+	return slices.Clone(f.circularReferences)
+}
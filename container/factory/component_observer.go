@@ -0,0 +1,25 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container"
+)
+
+// SetComponentObserver sets the observer notified of the component creations, nil removes it
+func (f *defaultFactory) SetComponentObserver(observer container.ComponentObserver) {
+	f.observer = observer
+}
+
+func (f *defaultFactory) notifyCreated(name string, meta *component_definition.Meta, start time.Time) {
+	if f.observer != nil {
+		f.observer.ComponentCreated(name, meta, time.Since(start))
+	}
+}
+
+func (f *defaultFactory) notifyInitialized(name string, meta *component_definition.Meta, start time.Time) {
+	if f.observer != nil {
+		f.observer.ComponentInitialized(name, meta, time.Since(start))
+	}
+}
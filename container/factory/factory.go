@@ -2,10 +2,13 @@ package factory
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/configure"
@@ -13,6 +16,7 @@ import (
 	"github.com/go-kid/ioc/container/support"
 	"github.com/go-kid/ioc/definition"
 	"github.com/go-kid/ioc/syslog"
+	"github.com/go-kid/ioc/util/sync2"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
@@ -28,8 +32,19 @@ type defaultFactory struct {
 	postProcessorRegistrationDelegate *PostProcessorRegistrationDelegate
 	registeredComponents              map[string]any
 	ctx                               context.Context
-	resolveStack                      []string
 	factoryHook                       container.FactoryHook
+	scopes                            *sync2.Map[string, container.Scope]
+	strictWiring                      bool
+	parallelism                       int
+	creationMu                        sync.Mutex
+	creations                         map[string]*singletonCreation
+	waiting                           map[*creationOwner]*creationWait
+	timings                           *startupTimings
+	failures                          *refreshFailures
+	circularMu                        sync.Mutex
+	circularReferences                []container.CircularReference
+	earlyHolders                      map[string][]string
+	observer                          container.ComponentObserver
 }
 
 func (f *defaultFactory) SetFactoryHook(hook container.FactoryHook) {
@@ -50,6 +65,12 @@ func (f *defaultFactory) emitEvent(phase, action, componentName, processorName s
 	})
 }
 
+// SetStrictWiring makes constructor parameters fail to resolve when more than one candidate
+// remains after WirePrimary filtering, instead of choosing one of them.
+func (f *defaultFactory) SetStrictWiring(strict bool) {
+	f.strictWiring = strict
+}
+
 func (f *defaultFactory) SetContext(ctx context.Context) {
 	f.ctx = ctx
 }
@@ -67,6 +88,10 @@ func Default() container.Factory {
 		singletonComponentRegistry:        support.DefaultSingletonComponentRegistry(),
 		postProcessorRegistrationDelegate: NewPostProcessorRegistrationDelegate(),
 		allowCircularReferences:           true,
+		scopes:                            sync2.New[string, container.Scope](),
+		creations:                         make(map[string]*singletonCreation),
+		waiting:                           make(map[*creationOwner]*creationWait),
+		earlyHolders:                      make(map[string][]string),
 	}
 	return f
 }
@@ -75,6 +100,7 @@ func (f *defaultFactory) PrepareComponents() error {
 	f.emitEvent("prepare", "phase_start", "", "", map[string]any{"phase": "PrepareComponents"})
 
 	singletonNames := f.singletonRegistry.GetSingletonNames()
+	registrations, _ := f.singletonRegistry.(container.RegistrationRegistry)
 	f.registeredComponents = make(map[string]any, len(singletonNames))
 	var factoryPostProcessors []container.ComponentFactoryPostProcessor
 	for _, name := range singletonNames {
@@ -91,9 +117,34 @@ func (f *defaultFactory) PrepareComponents() error {
 		if p, ok := singleton.(container.ComponentFactoryPostProcessor); ok {
 			factoryPostProcessors = append(factoryPostProcessors, p)
 		}
+		if registrations != nil {
+			if registration, ok := registrations.GetRegistration(name); ok {
+				f.definitionRegistry.GetMetaOrRegister(name, singleton).Declare(registration)
+			}
+		}
 		f.registeredComponents[name] = singleton
+		scope := definition.ScopeSingleton
+		if sc, ok := singleton.(definition.ScopeComponent); ok && sc.Scope() != "" {
+			scope = sc.Scope()
+		}
+		_, lazy := singleton.(definition.LazyInit)
 		f.emitEvent("prepare", "component_registered", name, "", map[string]any{
-			"type": reflect.TypeOf(singleton).String(),
+			"type":  reflect.TypeOf(singleton).String(),
+			"scope": scope,
+			"lazy":  lazy,
+		})
+	}
+
+	var overrides []container.ComponentOverride
+	if registry, ok := f.singletonRegistry.(container.OverrideRegistry); ok {
+		overrides = registry.GetOverrides()
+	}
+	for _, override := range overrides {
+		f.emitEvent("prepare", "component_overridden", override.Name, "", map[string]any{
+			"previous": fmt.Sprintf("%T", override.Previous),
+			"current":  fmt.Sprintf("%T", override.Current),
+			"policy":   string(override.Policy),
+			"replaced": override.Replaced,
 		})
 	}
 
@@ -134,6 +185,18 @@ func (f *defaultFactory) GetDefinitionRegistry() container.DefinitionRegistry {
 	return f.definitionRegistry
 }
 
+func (f *defaultFactory) RegisterScope(name string, scope container.Scope) {
+	if name == definition.ScopeSingleton || name == definition.ScopePrototype {
+		f.logger().Panicf("can not replace built-in scope '%s'", name)
+	}
+	f.scopes.Store(name, scope)
+	f.logger().Tracef("register scope '%s'", name)
+}
+
+func (f *defaultFactory) GetRegisteredScope(name string) (container.Scope, bool) {
+	return f.scopes.Load(name)
+}
+
 func (f *defaultFactory) Refresh() error {
 	f.emitEvent("refresh", "phase_start", "", "", map[string]any{"phase": "Refresh"})
 
@@ -143,6 +206,10 @@ func (f *defaultFactory) Refresh() error {
 		case definition.LazyInit:
 			continue
 		default:
+			if meta.IsCustomScope() {
+				f.logger().Debugf("skip component '%s' of scope '%s'", meta.Name(), meta.Scope())
+				continue
+			}
 			if cc, ok := meta.Raw.(definition.ConditionalComponent); ok {
 				if !cc.Condition(f.newConditionContext()) {
 					f.logger().Debugf("skip conditional component '%s'", meta.Name())
@@ -154,11 +221,22 @@ func (f *defaultFactory) Refresh() error {
 	}
 
 	slices.Sort(names)
-	for _, name := range names {
-		f.logger().Tracef("refresh component with name '%s'", name)
-		f.emitEvent("refresh", "component_resolving", name, "", nil)
-		_, err := f.doGetComponent(name)
-		if err != nil {
+	if f.parallelism > 1 {
+		if err := f.refreshParallel(names); err != nil {
+			return err
+		}
+	} else {
+		for _, name := range names {
+			f.logger().Tracef("refresh component with name '%s'", name)
+			f.emitEvent("refresh", "component_resolving", name, "", nil)
+			_, err := f.doGetComponent(f.getContext(), name)
+			if err != nil && f.failures == nil {
+				return err
+			}
+		}
+	}
+	if f.failures != nil {
+		if err := f.failures.err(); err != nil {
 			return err
 		}
 	}
@@ -168,6 +246,41 @@ func (f *defaultFactory) Refresh() error {
 	return nil
 }
 
+// GetSingletons returns the metas of all fully created singletons in creation order,
+// or nil if the singleton component registry does not list them.
+func (f *defaultFactory) GetSingletons() []*component_definition.Meta {
+	lister, ok := f.singletonComponentRegistry.(container.SingletonNameLister)
+	if !ok {
+		return nil
+	}
+	var singletons []*component_definition.Meta
+	for _, name := range lister.GetSingletonNames() {
+		meta, err := f.singletonComponentRegistry.GetSingleton(name, false)
+		if err != nil || meta == nil {
+			continue
+		}
+		singletons = append(singletons, meta)
+	}
+	return singletons
+}
+
+// DestroySingletons applies DestructionAwareComponentPostProcessor to every created singleton in reverse creation order.
+// A failed processor does not stop the destruction of other singletons, all errors are returned together.
+func (f *defaultFactory) DestroySingletons() error {
+	f.emitEvent("destroy", "phase_start", "", "", map[string]any{"phase": "DestroySingletons"})
+	singletons := f.GetSingletons()
+	var errs []error
+	for i := len(singletons) - 1; i >= 0; i-- {
+		name := singletons[i].Name()
+		f.logger().Tracef("destroy singleton '%s'", name)
+		if err := f.postProcessorRegistrationDelegate.ApplyBeforeDestruction(name, singletons[i].Raw); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	f.emitEvent("destroy", "phase_end", "", "", map[string]any{"phase": "DestroySingletons"})
+	return stderrors.Join(errs...)
+}
+
 type conditionContext struct {
 	registry  container.SingletonRegistry
 	configure configure.Configure
@@ -204,27 +317,59 @@ func (f *defaultFactory) GetComponents(opts ...container.Option) ([]any, error)
 }
 
 func (f *defaultFactory) GetComponentByName(name string) (any, error) {
-	m, err := f.doGetComponent(name)
+	return f.GetComponentByNameWithContext(f.getContext(), name)
+}
+
+// GetComponentByNameWithContext is like GetComponentByName, but resolves scoped components
+// (and the scoped dependencies of newly created components) against ctx.
+// A lookup outside of a component creation claims the singletons it creates, so that concurrent lookups
+// (e.g. of a lazy component by container.Lazy) create them once.
+func (f *defaultFactory) GetComponentByNameWithContext(ctx context.Context, name string) (any, error) {
+	if _, owned := creationOwnerOf(ctx); !owned && len(resolveStackOf(ctx)) == 0 {
+		ctx = withCreationOwner(ctx)
+	}
+	m, err := f.doGetComponent(ctx, name)
 	if err != nil {
 		return nil, err
 	}
 	return m.Raw, nil
 }
 
-func (f *defaultFactory) pushResolveStack(name string) {
-	f.resolveStack = append(f.resolveStack, name)
+type resolveStackKey struct{}
+
+// resolveStack is the chain of components being resolved, it is kept in the context
+// so that each goroutine creating components reports its own chain.
+type resolveStack struct {
+	name string
+	// via is the field or the constructor parameter of parent injected with name
+	via    string
+	parent *resolveStack
+}
+
+func withResolveStack(ctx context.Context, name string) context.Context {
+	parent, _ := ctx.Value(resolveStackKey{}).(*resolveStack)
+	var via string
+	if parent != nil {
+		via = injectionSiteOf(ctx)
+	}
+	ctx = context.WithValue(ctx, resolveStackKey{}, &resolveStack{name: name, via: via, parent: parent})
+	return withInjectionSite(ctx, "")
 }
 
-func (f *defaultFactory) popResolveStack() {
-	if len(f.resolveStack) > 0 {
-		f.resolveStack = f.resolveStack[:len(f.resolveStack)-1]
+func resolveStackOf(ctx context.Context) []string {
+	var names []string
+	for s, _ := ctx.Value(resolveStackKey{}).(*resolveStack); s != nil; s = s.parent {
+		names = append(names, s.name)
 	}
+	slices.Reverse(names)
+	return names
 }
 
-func (f *defaultFactory) formatDependencyChain(failedName string, reason string) string {
+func (f *defaultFactory) formatDependencyChain(ctx context.Context, failedName string, reason string) string {
+	stack := resolveStackOf(ctx)
 	var sb strings.Builder
 	sb.WriteString("dependency resolution failed:\n")
-	for i, name := range f.resolveStack {
+	for i, name := range stack {
 		sb.WriteString(strings.Repeat("  ", i))
 		if i > 0 {
 			sb.WriteString("-> ")
@@ -232,18 +377,54 @@ func (f *defaultFactory) formatDependencyChain(failedName string, reason string)
 		sb.WriteString(name)
 		sb.WriteString("\n")
 	}
-	sb.WriteString(strings.Repeat("  ", len(f.resolveStack)))
+	sb.WriteString(strings.Repeat("  ", len(stack)))
 	sb.WriteString("-> ")
 	sb.WriteString(failedName)
 	sb.WriteString(fmt.Sprintf(" (%s)", reason))
 	return sb.String()
 }
 
-func (f *defaultFactory) doGetComponent(name string) (*component_definition.Meta, error) {
+// withInjectionChain fills the dependency chain of the resolution error in err if it has none yet
+func withInjectionChain(ctx context.Context, err error) error {
+	var injectionErr container.InjectionError
+	if errors.As(err, &injectionErr) && len(injectionErr.Point().Chain) == 0 {
+		injectionErr.Point().Chain = resolveStackOf(ctx)
+	}
+	return err
+}
+
+func (f *defaultFactory) constructorPoint(ctx context.Context, componentName string, paramIndex int) container.InjectionPoint {
+	return container.InjectionPoint{
+		Component: componentName,
+		Field:     fmt.Sprintf("param[%d]", paramIndex),
+		Chain:     resolveStackOf(ctx),
+	}
+}
+
+func (f *defaultFactory) doGetComponent(ctx context.Context, name string) (*component_definition.Meta, error) {
 	meta := f.definitionRegistry.GetMetaByName(name)
 	if meta != nil && meta.IsPrototype() {
+		if err := f.checkCircularReference(ctx, name); err != nil {
+			return nil, err
+		}
 		f.logger().Debugf("creating new prototype instance for '%s'", name)
-		return f.createComponent(name)
+		return f.createComponent(withResolveStack(ctx, name), name)
+	}
+	if meta != nil && meta.IsCustomScope() {
+		return f.getScopedComponent(ctx, name, meta.Scope())
+	}
+
+	if owner, ok := creationOwnerOf(ctx); ok {
+		release, err := f.acquireCreation(ctx, owner, name)
+		if err != nil {
+			return nil, err
+		}
+		if release != nil {
+			defer release()
+		}
+	}
+	if f.failures != nil && f.failures.has(name) {
+		return nil, &dependencyFailedError{name: name}
 	}
 
 	sharedInstance, err := f.singletonComponentRegistry.GetSingleton(name, true)
@@ -254,35 +435,120 @@ func (f *defaultFactory) doGetComponent(name string) (*component_definition.Meta
 		if f.singletonComponentRegistry.IsSingletonCurrentlyInCreation(name) {
 			f.logger().Debugf("returning eagerly cached instance of singleton '%s' that is not fully initialized yet - a consequence of a circular reference",
 				name)
+			f.recordCircularReference(ctx, name)
 		} else {
 			f.logger().Debugf("returning eagerly cached instance of singleton '%s'", name)
 		}
 		return sharedInstance, nil
 	}
+	if err := f.checkCircularReference(ctx, name); err != nil {
+		return nil, err
+	}
 
-	f.pushResolveStack(name)
 	sharedInstance, err = f.singletonComponentRegistry.GetSingletonOrCreateByFactory(name,
 		container.FuncSingletonFactory(func() (*component_definition.Meta, error) {
-			return f.createComponent(name)
+			return f.createComponent(withResolveStack(ctx, name), name)
 		}))
-	f.popResolveStack()
 	if err != nil {
+		f.dropFailedSingleton(name)
+		if f.failures != nil {
+			return nil, f.recordFailure(ctx, name, err)
+		}
 		return nil, err
 	}
+	f.forgetEarlyHolders(name)
 	return sharedInstance, nil
 }
 
-func (f *defaultFactory) createComponent(name string) (*component_definition.Meta, error) {
+func (f *defaultFactory) getScopedComponent(ctx context.Context, name, scopeName string) (*component_definition.Meta, error) {
+	scope, ok := f.GetRegisteredScope(scopeName)
+	if !ok {
+		return nil, errors.Errorf("no scope registered for scope name '%s' of component '%s'", scopeName, name)
+	}
+	f.logger().Debugf("get instance of '%s' from scope '%s'", name, scopeName)
+	m, err := scope.Get(ctx, name, container.FuncSingletonFactory(func() (*component_definition.Meta, error) {
+		m, err := f.createComponent(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		scope.RegisterDestructionCallback(ctx, name, func() {
+			f.destroyScopedComponent(name, m)
+		})
+		return m, nil
+	}))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "get component '%s' from scope '%s'", name, scopeName)
+	}
+	return m, nil
+}
+
+func (f *defaultFactory) destroyScopedComponent(name string, m *component_definition.Meta) {
+	f.logger().Debugf("destroy scoped component '%s'", name)
+	if err := f.postProcessorRegistrationDelegate.ApplyBeforeDestruction(name, m.Raw); err != nil {
+		f.logger().Errorf("destroy scoped component '%s' failed: %+v", name, err)
+	}
+	var err error
+	if c, ok := m.Raw.(definition.CloserComponentWithContext); ok {
+		err = c.CloseWithContext(context.Background())
+	} else if c, ok := m.Raw.(definition.CloserComponent); ok {
+		err = c.Close()
+	}
+	if err != nil {
+		f.logger().Errorf("invoking Close() for scoped component '%s' failed: %+v", name, err)
+	}
+}
+
+// newScopedInstance creates a new instance for a custom scoped component, by constructor if registered,
+// otherwise from the zero value of the registered component type, populated by injection like the registered one.
+// The registered definition is kept as a template, the new instance is scanned into a standalone definition.
+func (f *defaultFactory) newScopedInstance(ctx context.Context, name string, meta *component_definition.Meta) (*component_definition.Meta, error) {
+	var instance any
+	if constructor, ok := f.constructorOf(name); ok {
+		var err error
+		instance, err = f.invokeConstructor(ctx, name, constructor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invoke constructor for component '%s'", name)
+		}
+	} else {
+		if meta.Type.Kind() != reflect.Ptr || meta.Type.Elem().Kind() != reflect.Struct {
+			return nil, errors.Errorf("scoped component '%s' must be a struct pointer or registered by constructor, got %s", name, meta.Type)
+		}
+		instance = reflect.New(meta.Type.Elem()).Interface()
+	}
+	scoped, err := component_definition.CreateProxy(meta, name, instance)
+	if err != nil {
+		return nil, err
+	}
+	registry := support.DefaultDefinitionRegistry()
+	registry.RegisterMeta(scoped)
+	for _, processor := range f.definitionRegistryPostProcessors {
+		if err := processor.PostProcessDefinitionRegistry(registry, scoped.Raw, name); err != nil {
+			return nil, errors.Wrapf(err, "scan definition for scoped component '%s'", name)
+		}
+	}
+	return scoped, nil
+}
+
+func (f *defaultFactory) createComponent(ctx context.Context, name string) (*component_definition.Meta, error) {
 	meta := f.definitionRegistry.GetMetaByName(name)
 	if meta == nil {
 		return nil, errors.Errorf("component definition with name '%s' not found", name)
 	}
 
 	f.emitEvent("refresh", "component_creating", name, "", map[string]any{"type": meta.Type.String()})
+	start := time.Now()
+	ctx, measured := f.measureCreation(ctx, name)
+	defer measured()
 
-	if constructor, ok := f.singletonRegistry.GetConstructor(name); ok {
+	if meta.IsCustomScope() {
+		scoped, err := f.newScopedInstance(ctx, name, meta)
+		if err != nil {
+			return nil, err
+		}
+		meta = scoped
+	} else if constructor, ok := f.constructorOf(name); ok {
 		f.emitEvent("refresh", "constructor_invoking", name, "", nil)
-		instance, err := f.invokeConstructor(name, constructor)
+		instance, err := f.invokeConstructor(ctx, name, constructor)
 		if err != nil {
 			return nil, errors.Wrapf(err, "invoke constructor for component '%s'", name)
 		}
@@ -306,21 +572,27 @@ func (f *defaultFactory) createComponent(name string) (*component_definition.Met
 	}
 	if instantiation != nil {
 		if instantiation != meta.Raw {
-			return component_definition.CreateProxy(meta, name, instantiation)
-		} else {
-			return meta, nil
+			meta, err = component_definition.CreateProxy(meta, name, instantiation)
+			if err != nil {
+				return nil, err
+			}
 		}
+		f.notifyCreated(name, meta, start)
+		f.notifyInitialized(name, meta, start)
+		return meta, nil
 	}
 
-	instance, err := f.doCreateComponent(name, meta)
+	f.notifyCreated(name, meta, start)
+	instance, err := f.doCreateComponent(ctx, name, meta)
 	if err != nil {
 		return nil, err
 	}
+	f.notifyInitialized(name, instance, start)
 
 	return instance, nil
 }
 
-func (f *defaultFactory) doCreateComponent(name string, meta *component_definition.Meta) (*component_definition.Meta, error) {
+func (f *defaultFactory) doCreateComponent(ctx context.Context, name string, meta *component_definition.Meta) (*component_definition.Meta, error) {
 	earlySingletonExposure := meta.IsSingleton() && !meta.IsPrototype() && f.allowCircularReferences && f.singletonComponentRegistry.IsSingletonCurrentlyInCreation(name)
 	if earlySingletonExposure {
 		f.logger().Debugf("eagerly caching bean '%s' to allow for resolving potential circular references", name)
@@ -332,14 +604,16 @@ func (f *defaultFactory) doCreateComponent(name string, meta *component_definiti
 	var exposedComponent = meta
 
 	f.emitEvent("refresh", "populating", name, "", nil)
-	err := f.populateComponent(name, meta)
+	measured := f.measurePhase(ctx, name, populationPhase)
+	err := f.populateComponent(ctx, name, meta)
+	measured()
 	if err != nil {
 		return nil, err
 	}
 	f.emitEvent("refresh", "populated", name, "", nil)
 
 	instance := meta.Raw
-	wrappedInstance, err := f.postProcessorRegistrationDelegate.InitializeComponentWithContext(f.getContext(), name, instance)
+	wrappedInstance, err := f.postProcessorRegistrationDelegate.InitializeComponentWithContext(ctx, name, instance)
 	if err != nil {
 		return nil, err
 	}
@@ -384,21 +658,38 @@ func (f *defaultFactory) doCreateComponent(name string, meta *component_definiti
 	return exposedComponent, nil
 }
 
-func (f *defaultFactory) populateComponent(name string, meta *component_definition.Meta) error {
+func (f *defaultFactory) populateComponent(ctx context.Context, name string, meta *component_definition.Meta) error {
 	err := f.postProcessorRegistrationDelegate.ResolveAfterInstantiation(meta, name)
 	if err != nil {
-		return err
+		return withInjectionChain(ctx, err)
 	}
 	if properties := meta.GetComponentProperties(); len(properties) > 0 {
 		f.logger().Tracef("inject dependencies for '%s'", name)
 		for _, node := range meta.GetComponentProperties() {
+			if binder, ok := container.AsProviderBinder(node.Value); ok {
+				if len(node.Injects) != 0 {
+					dependency := node.Injects[0]
+					f.logger().Tracef("bind provider of '%s' to '%s'", dependency.Name(), node.StructField.Name)
+					binder.BindProvider(f, dependency.Name())
+					f.emitEvent("refresh", "dependency_injected", name, "", map[string]any{
+						"dependency": dependency.Name(),
+						"field":      node.StructField.Name,
+						"depType":    "provider",
+					})
+				}
+				continue
+			}
 			if dependencies := node.Injects; len(dependencies) != 0 {
 				var injects []*component_definition.Meta
 				for _, dependency := range node.Injects {
 					f.logger().Tracef("found dependency '%s' for '%s', start to get or create", dependency.Name(), name)
-					component, err := f.doGetComponent(dependency.Name())
+					component, err := f.doGetComponent(withInjectionSite(ctx, node.StructField.Name), dependency.Name())
 					if err != nil {
-						return fmt.Errorf("%s\n%w", f.formatDependencyChain(dependency.Name(), "not found or creation failed"), err)
+						return &injectionError{
+							field: node.StructField.Name,
+							tag:   propertyTag(node),
+							err:   fmt.Errorf("%s\n%w", f.formatDependencyChain(ctx, dependency.Name(), "not found or creation failed"), err),
+						}
 					}
 					injects = append(injects, component)
 
@@ -415,7 +706,7 @@ func (f *defaultFactory) populateComponent(name string, meta *component_definiti
 				}
 				err = node.Inject(injects)
 				if err != nil {
-					return err
+					return withInjectionChain(ctx, err)
 				}
 			}
 		}
@@ -445,55 +736,85 @@ func (f *defaultFactory) genProxyComponent(origin *component_definition.Meta, na
 	return component_definition.CreateProxy(origin, name, newComponent)
 }
 
-func (f *defaultFactory) invokeConstructor(name string, constructor any) (any, error) {
-	fnType := reflect.TypeOf(constructor)
-	fnValue := reflect.ValueOf(constructor)
+// constructorOf returns the constructor registered for name, with its parameter annotations
+// if the singleton registry keeps them (container.ConstructorRegistry).
+func (f *defaultFactory) constructorOf(name string) (*component_definition.Constructor, bool) {
+	if registry, ok := f.singletonRegistry.(container.ConstructorRegistry); ok {
+		return registry.GetConstructorDefinition(name)
+	}
+	fn, ok := f.singletonRegistry.GetConstructor(name)
+	if !ok {
+		return nil, false
+	}
+	return component_definition.NewConstructor(fn), true
+}
+
+func (f *defaultFactory) invokeConstructor(ctx context.Context, name string, constructor *component_definition.Constructor) (any, error) {
+	fnType := constructor.Type
+	fnValue := reflect.ValueOf(constructor.Fn)
 
 	f.logger().Tracef("invoking constructor %s for component '%s' with %d params", fnType.String(), name, fnType.NumIn())
 
 	args := make([]reflect.Value, fnType.NumIn())
 	for i := 0; i < fnType.NumIn(); i++ {
 		paramType := fnType.In(i)
-		resolved, err := f.resolveConstructorParam(name, i, paramType)
+		resolved, err := f.resolveConstructorParam(ctx, name, i, paramType, constructor.Param(i))
 		if err != nil {
-			return nil, errors.Wrapf(err, "resolve parameter %d (type %s)", i, paramType)
+			return nil, &injectionError{
+				field: fmt.Sprintf("param[%d]", i),
+				err:   errors.Wrapf(err, "resolve parameter %d (type %s)", i, paramType),
+			}
 		}
 		args[i] = resolved
 	}
 
+	measured := f.measurePhase(ctx, name, instantiationPhase)
 	results := fnValue.Call(args)
+	measured()
 
 	if fnType.NumOut() == 2 && !results[1].IsNil() {
-		return nil, errors.Wrapf(results[1].Interface().(error), "constructor returned error")
+		return nil, &container.ConstructorError{
+			InjectionPoint: container.InjectionPoint{Component: name, Chain: resolveStackOf(ctx)},
+			Err:            results[1].Interface().(error),
+		}
 	}
 	return results[0].Interface(), nil
 }
 
-func (f *defaultFactory) resolveConstructorParam(componentName string, paramIndex int, paramType reflect.Type) (reflect.Value, error) {
-	isSlice := paramType.Kind() == reflect.Slice
+func (f *defaultFactory) recordConstructorDependency(componentName string, paramIndex int, paramType reflect.Type, dependency *component_definition.Meta) {
+	if dependent := f.definitionRegistry.GetMetaByName(componentName); dependent != nil {
+		dependency.DependOn(dependent)
+	}
+	depType := "pointer"
+	if paramType.Kind() == reflect.Interface ||
+		(paramType.Kind() == reflect.Slice && paramType.Elem().Kind() == reflect.Interface) {
+		depType = "interface"
+	}
+	f.emitEvent("refresh", "dependency_injected", componentName, "", map[string]any{
+		"dependency": dependency.Name(),
+		"field":      fmt.Sprintf("param[%d]", paramIndex),
+		"depType":    depType,
+	})
+}
 
-	var elemType reflect.Type
+// resolveConstructorParam resolves a constructor parameter by type,
+// param is the optional annotation narrowing the candidates by name and qualifier like a `wire` tag does.
+func (f *defaultFactory) resolveConstructorParam(ctx context.Context, componentName string, paramIndex int, paramType reflect.Type, param *component_definition.ConstructorParam) (reflect.Value, error) {
+	if param.IsConfiguration() {
+		return f.resolveConstructorParamValue(componentName, paramType, param)
+	}
+	isSlice := paramType.Kind() == reflect.Slice
+	elemType := paramType
 	if isSlice {
 		elemType = paramType.Elem()
-	} else {
-		elemType = paramType
 	}
-
-	var typeOption container.Option
-	switch elemType.Kind() {
-	case reflect.Ptr:
-		typeOption = container.Type(elemType)
-	case reflect.Interface:
-		typeOption = container.InterfaceType(elemType)
-	default:
-		return reflect.Value{}, errors.Errorf("unsupported parameter type %s, must be pointer, interface, or slice of them", paramType)
+	validMetas, err := f.constructorParamCandidates(paramType, param)
+	if err != nil {
+		return reflect.Value{}, err
 	}
+	annotated := param.IsSelective()
 
-	metas := f.definitionRegistry.GetMetas(typeOption)
-
-	validMetas := lo.Filter(metas, func(m *component_definition.Meta, _ int) bool { return m != nil })
-
-	if len(validMetas) == 0 && !isSlice && elemType.Kind() == reflect.Ptr {
+	if len(validMetas) == 0 && !isSlice && !annotated && elemType.Kind() == reflect.Ptr {
 		if resolved, err := f.resolveConfigurationProperties(elemType); err != nil {
 			return reflect.Value{}, errors.Wrapf(err, "resolve ConfigurationProperties parameter %d (type %s)", paramIndex, paramType)
 		} else if resolved.IsValid() {
@@ -505,30 +826,103 @@ func (f *defaultFactory) resolveConstructorParam(componentName string, paramInde
 		if isSlice {
 			return reflect.MakeSlice(paramType, 0, 0), nil
 		}
-		reason := fmt.Sprintf("no component found for constructor parameter[%d] type %s", paramIndex, paramType)
-		return reflect.Value{}, fmt.Errorf("%s\n%s", reason, f.formatDependencyChain(paramType.String(), reason))
+		if !param.IsRequired() {
+			return reflect.Zero(paramType), nil
+		}
+		noSuchErr := &container.NoSuchComponentError{
+			InjectionPoint: f.constructorPoint(ctx, componentName, paramIndex),
+			Type:           paramType,
+		}
+		if annotated {
+			noSuchErr.Reason = fmt.Sprintf("matching %s", param)
+		}
+		return reflect.Value{}, noSuchErr
 	}
 
 	if isSlice {
 		slice := reflect.MakeSlice(paramType, len(validMetas), len(validMetas))
 		for i, m := range validMetas {
-			dep, err := f.doGetComponent(m.Name())
+			dep, err := f.doGetComponent(withInjectionSite(ctx, fmt.Sprintf("param[%d]", paramIndex)), m.Name())
 			if err != nil {
 				return reflect.Value{}, errors.Wrapf(err, "resolve slice element %d", i)
 			}
+			f.recordConstructorDependency(componentName, paramIndex, paramType, dep)
 			slice.Index(i).Set(dep.Value)
 		}
 		return slice, nil
 	}
 
 	selected := component_definition.SelectBestCandidate(validMetas)
-	dep, err := f.doGetComponent(selected.Name())
+	if f.strictWiring {
+		var err error
+		selected, err = component_definition.SelectUniqueCandidate(validMetas)
+		if err != nil {
+			var ambiguousErr *container.AmbiguousComponentError
+			if errors.As(err, &ambiguousErr) {
+				ambiguousErr.InjectionPoint, ambiguousErr.Type = f.constructorPoint(ctx, componentName, paramIndex), paramType
+			}
+			return reflect.Value{}, errors.WithMessage(err, "strict wiring mode")
+		}
+	}
+	dep, err := f.doGetComponent(withInjectionSite(ctx, fmt.Sprintf("param[%d]", paramIndex)), selected.Name())
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	f.recordConstructorDependency(componentName, paramIndex, paramType, dep)
 	return dep.Value, nil
 }
 
+// resolveConstructorParamValue resolves a parameter bound to a configuration value
+// by the same property pipeline (config quote, expression, value) fields with a `value` tag go through.
+func (f *defaultFactory) resolveConstructorParamValue(componentName string, paramType reflect.Type, param *component_definition.ConstructorParam) (reflect.Value, error) {
+	meta := f.definitionRegistry.GetMetaByName(componentName)
+	if meta == nil {
+		return reflect.Value{}, errors.Errorf("component definition with name '%s' not found", componentName)
+	}
+	prop := param.NewProperty(component_definition.NewHolder(meta), paramType)
+	if err := f.postProcessorRegistrationDelegate.ResolveProperties([]*component_definition.Property{prop}, meta.Raw, componentName); err != nil {
+		return reflect.Value{}, errors.WithMessagef(err, "resolve constructor %s", param)
+	}
+	return prop.Value, nil
+}
+
+// constructorParamCandidates returns the components matching a constructor parameter of paramType
+func (f *defaultFactory) constructorParamCandidates(paramType reflect.Type, param *component_definition.ConstructorParam) ([]*component_definition.Meta, error) {
+	elemType := paramType
+	if paramType.Kind() == reflect.Slice {
+		elemType = paramType.Elem()
+	}
+	var typeOption container.Option
+	switch elemType.Kind() {
+	case reflect.Ptr:
+		typeOption = container.Type(elemType)
+	case reflect.Interface:
+		typeOption = container.InterfaceType(elemType)
+	default:
+		return nil, errors.Errorf("unsupported parameter type %s, must be pointer, interface, or slice of them, or bound to configuration by Param(i).Value", paramType)
+	}
+
+	metas := f.definitionRegistry.GetMetas(typeOption)
+	validMetas := lo.Filter(metas, func(m *component_definition.Meta, _ int) bool { return m != nil })
+	if param.IsSelective() {
+		validMetas = filterConstructorParamCandidates(validMetas, param)
+	}
+	return validMetas, nil
+}
+
+func filterConstructorParamCandidates(metas []*component_definition.Meta, param *component_definition.ConstructorParam) []*component_definition.Meta {
+	if param.Name != "" {
+		metas = lo.Filter(metas, func(m *component_definition.Meta, _ int) bool { return m.Name() == param.Name })
+	}
+	if _, isQualifier := param.Args().Find(component_definition.ArgQualifier); isQualifier {
+		metas = lo.Filter(metas, func(m *component_definition.Meta, _ int) bool {
+			qualifier, ok := m.Qualifier()
+			return ok && param.Args().Has(component_definition.ArgQualifier, qualifier)
+		})
+	}
+	return metas
+}
+
 func (f *defaultFactory) resolveConfigurationProperties(ptrType reflect.Type) (reflect.Value, error) {
 	instance := reflect.New(ptrType.Elem()).Interface()
 	cp, ok := instance.(definition.ConfigurationProperties)
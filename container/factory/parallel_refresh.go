@@ -0,0 +1,336 @@
+package factory
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container"
+	"github.com/pkg/errors"
+)
+
+// SetParallelism makes Refresh create independent components concurrently with at most workers goroutines,
+// workers <= 1 keeps the serial refresh.
+// The post processors and the Init methods of the components must be safe for concurrent use.
+func (f *defaultFactory) SetParallelism(workers int) {
+	f.parallelism = workers
+}
+
+type creationOwnerKey struct{}
+
+// creationOwner identifies the worker creating components, components are created re-entrantly by their owner
+// (circular references), other workers wait for them to be created.
+type creationOwner struct {
+	// pointers to zero-size values may be equal, the field keeps the owners distinct
+	_ byte
+}
+
+func withCreationOwner(ctx context.Context) context.Context {
+	return context.WithValue(ctx, creationOwnerKey{}, &creationOwner{})
+}
+
+func creationOwnerOf(ctx context.Context) (*creationOwner, bool) {
+	owner, ok := ctx.Value(creationOwnerKey{}).(*creationOwner)
+	return owner, ok
+}
+
+type singletonCreation struct {
+	owner *creationOwner
+	done  chan struct{}
+}
+
+// creationWait is the singleton a worker waits for while another worker creates it
+type creationWait struct {
+	owner *creationOwner
+	name  string
+	// ctx resolves name, it keeps the resolve stack of the waiting worker
+	ctx context.Context
+}
+
+// acquireCreation claims the creation of singleton name resolved by ctx for owner, waiting while another worker creates it.
+// The returned release must be called once the singleton is created, it is nil if owner is already creating it.
+func (f *defaultFactory) acquireCreation(ctx context.Context, owner *creationOwner, name string) (release func(), err error) {
+	f.creationMu.Lock()
+	for {
+		c, ok := f.creations[name]
+		if !ok {
+			c = &singletonCreation{owner: owner, done: make(chan struct{})}
+			f.creations[name] = c
+			f.creationMu.Unlock()
+			return func() {
+				f.creationMu.Lock()
+				delete(f.creations, name)
+				f.creationMu.Unlock()
+				close(c.done)
+			}, nil
+		}
+		if c.owner == owner {
+			f.creationMu.Unlock()
+			return nil, nil
+		}
+		if f.isWaitingFor(c.owner, owner) {
+			// the worker creating name waits for this one, resolve the circular reference by the early reference
+			ref, found := f.circularReferenceAcross(ctx, c.owner, owner, name)
+			f.creationMu.Unlock()
+			early, err := f.singletonComponentRegistry.GetSingleton(name, true)
+			if err != nil {
+				return nil, err
+			}
+			if early == nil {
+				point := container.InjectionPoint{Component: name}
+				if stack := resolveStackOf(ctx); len(stack) != 0 {
+					point = container.InjectionPoint{Component: stack[len(stack)-1], Field: injectionSiteOf(ctx), Chain: stack}
+				}
+				return nil, errors.WithMessage(&container.CircularReferenceError{
+					InjectionPoint:    point,
+					CircularReference: ref,
+				}, "across parallel workers, the component is not early exposed")
+			}
+			if found {
+				f.addCircularReference(name, ref)
+			}
+			return nil, nil
+		}
+		f.waiting[owner] = &creationWait{owner: c.owner, name: name, ctx: ctx}
+		f.creationMu.Unlock()
+		<-c.done
+		f.creationMu.Lock()
+		delete(f.waiting, owner)
+	}
+}
+
+// isWaitingFor reports whether owner waits for target directly or through other workers
+func (f *defaultFactory) isWaitingFor(owner, target *creationOwner) bool {
+	for o := owner; o != target; {
+		wait, ok := f.waiting[o]
+		if !ok {
+			return false
+		}
+		o = wait.owner
+	}
+	return true
+}
+
+// refreshParallel creates the components of names with a bounded worker pool.
+// Components are grouped by strongly connected components of the dependency graph,
+// a group is created by a single worker once all the groups it depends on are created.
+func (f *defaultFactory) refreshParallel(names []string) error {
+	graph := f.dependencyGraph(names)
+	groups := stronglyConnectedComponents(names, graph)
+	groupOf := make(map[string]int, len(names))
+	for i, group := range groups {
+		for _, name := range group {
+			groupOf[name] = i
+		}
+	}
+	pending := make([]int, len(groups))
+	dependents := make([][]int, len(groups))
+	for i, group := range groups {
+		seen := make(map[int]bool)
+		for _, name := range group {
+			for _, dep := range graph[name] {
+				if d := groupOf[dep]; d != i && !seen[d] {
+					seen[d] = true
+					pending[i]++
+					dependents[d] = append(dependents[d], i)
+				}
+			}
+		}
+	}
+	f.logger().Debugf("refresh %d components in %d groups with %d workers", len(names), len(groups), f.parallelism)
+
+	type result struct {
+		group int
+		err   error
+	}
+	ready := make(chan int, len(groups))
+	results := make(chan result, len(groups))
+	var wg sync.WaitGroup
+	for i := 0; i < f.parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range ready {
+				results <- result{group: group, err: f.refreshGroup(groups[group])}
+			}
+		}()
+	}
+
+	running := 0
+	for i := range groups {
+		if pending[i] == 0 {
+			ready <- i
+			running++
+		}
+	}
+	var errs []error
+	for running > 0 {
+		r := <-results
+		running--
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		if len(errs) != 0 {
+			continue
+		}
+		for _, d := range dependents[r.group] {
+			if pending[d]--; pending[d] == 0 {
+				ready <- d
+				running++
+			}
+		}
+	}
+	close(ready)
+	wg.Wait()
+	if len(errs) != 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (f *defaultFactory) refreshGroup(names []string) error {
+	ctx := withCreationOwner(f.getContext())
+	for _, name := range names {
+		f.logger().Tracef("refresh component with name '%s'", name)
+		f.emitEvent("refresh", "component_resolving", name, "", nil)
+		if _, err := f.doGetComponent(ctx, name); err != nil && f.failures == nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependencyGraph computes the dependencies between the components of names ahead of their creation,
+// by matching the scanned component properties through the property pipeline and by the constructor signatures.
+// It only decides the creation order, dependencies it misses are still created on demand.
+func (f *defaultFactory) dependencyGraph(names []string) map[string][]string {
+	graph := make(map[string][]string, len(names))
+	for _, name := range names {
+		meta := f.definitionRegistry.GetMetaByName(name)
+		if meta == nil {
+			continue
+		}
+		graph[name] = append(f.fieldDependencies(name, meta), f.constructorDependencies(name)...)
+	}
+	return graph
+}
+
+// fieldDependencies matches the components injected into the fields of meta, except the Provider fields
+func (f *defaultFactory) fieldDependencies(name string, meta *component_definition.Meta) []string {
+	properties := probeProperties(meta.GetComponentProperties())
+	if len(properties) == 0 {
+		return nil
+	}
+	if err := f.postProcessorRegistrationDelegate.MatchProperties(properties, meta.Raw, name); err != nil {
+		f.logger().Debugf("match dependencies of '%s' ahead of creation failed: %v", name, err)
+	}
+	var deps []string
+	for _, prop := range properties {
+		if _, isProvider := container.AsProviderBinder(prop.Value); isProvider {
+			continue
+		}
+		for _, dep := range prop.Injects {
+			// self injects are removed by Property.Inject
+			if dep != nil && dep.Name() != name {
+				deps = append(deps, dep.Name())
+			}
+		}
+	}
+	return deps
+}
+
+// constructorDependencies matches the components injected into the constructor parameters of the component name
+func (f *defaultFactory) constructorDependencies(name string) []string {
+	constructor, ok := f.constructorOf(name)
+	if !ok {
+		return nil
+	}
+	var deps []string
+	for i := 0; i < constructor.Type.NumIn(); i++ {
+		param, paramType := constructor.Param(i), constructor.Type.In(i)
+		if param.IsConfiguration() {
+			continue
+		}
+		candidates, err := f.constructorParamCandidates(paramType, param)
+		if err != nil || len(candidates) == 0 {
+			continue
+		}
+		if paramType.Kind() != reflect.Slice {
+			candidates = []*component_definition.Meta{component_definition.SelectBestCandidate(candidates)}
+		}
+		for _, dep := range candidates {
+			deps = append(deps, dep.Name())
+		}
+	}
+	return deps
+}
+
+// probeProperties copies the properties on detached fields to match them ahead of creation,
+// the originals are matched again when the component is populated.
+func probeProperties(properties []*component_definition.Property) []*component_definition.Property {
+	probes := make([]*component_definition.Property, len(properties))
+	for i, prop := range properties {
+		probe := *prop
+		probe.Field = prop.Field.Detached()
+		probe.Injects = nil
+		probe.Configurations = make(map[string]any)
+		probes[i] = &probe
+	}
+	return probes
+}
+
+// stronglyConnectedComponents groups names by the cycles of graph (Tarjan's algorithm),
+// names in a group are sorted, groups are ordered dependencies first.
+func stronglyConnectedComponents(names []string, graph map[string][]string) [][]string {
+	var (
+		index   = make(map[string]int, len(names))
+		lowLink = make(map[string]int, len(names))
+		onStack = make(map[string]bool, len(names))
+		stack   []string
+		groups  [][]string
+		visit   func(name string)
+	)
+	for _, name := range names {
+		graph[name] = slices.DeleteFunc(graph[name], func(dep string) bool {
+			_, ok := graph[dep]
+			return !ok
+		})
+	}
+	visit = func(name string) {
+		index[name] = len(index)
+		lowLink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+		for _, dep := range graph[name] {
+			if _, visited := index[dep]; !visited {
+				visit(dep)
+				lowLink[name] = min(lowLink[name], lowLink[dep])
+			} else if onStack[dep] {
+				lowLink[name] = min(lowLink[name], index[dep])
+			}
+		}
+		if lowLink[name] == index[name] {
+			var group []string
+			for {
+				n := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[n] = false
+				group = append(group, n)
+				if n == name {
+					break
+				}
+			}
+			slices.Sort(group)
+			groups = append(groups, group)
+		}
+	}
+	for _, name := range names {
+		if _, visited := index[name]; !visited {
+			visit(name)
+		}
+	}
+	return groups
+}
@@ -21,6 +21,7 @@ type PostProcessorRegistrationDelegate struct {
 	hasInstantiationAwareComponentPostProcessor bool
 	hasDestructionAwareComponentPostProcessor   bool
 	factoryHook                                 container.FactoryHook
+	timings                                     *startupTimings
 }
 
 func (f *PostProcessorRegistrationDelegate) emitEvent(phase, action, componentName, processorName string, details map[string]any) {
@@ -41,10 +42,10 @@ func NewPostProcessorRegistrationDelegate() *PostProcessorRegistrationDelegate {
 }
 
 func (f *PostProcessorRegistrationDelegate) RegisterComponentPostProcessors(ps container.ComponentPostProcessor, name string) {
-	switch ps.(type) {
-	case container.InstantiationAwareComponentPostProcessor:
+	if _, ok := ps.(container.InstantiationAwareComponentPostProcessor); ok {
 		f.hasInstantiationAwareComponentPostProcessor = true
-	case container.DestructionAwareComponentPostProcessor:
+	}
+	if _, ok := ps.(container.DestructionAwareComponentPostProcessor); ok {
 		f.hasDestructionAwareComponentPostProcessor = true
 	}
 
@@ -133,7 +134,9 @@ func (f *PostProcessorRegistrationDelegate) InitializeComponentWithContext(ctx c
 	}
 
 	f.emitEvent("refresh", "init_method_calling", name, "", nil)
+	measured := f.timings.measureInit(name)
 	err = f.invokeInitMethods(ctx, name, wrappedComponent)
+	measured()
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +182,9 @@ func (f *PostProcessorRegistrationDelegate) applyPostProcessBeforeInitialization
 		err     error
 	)
 	for _, processor := range f.componentPostProcessors {
+		measured := f.timings.measureProcessor(name, processor)
 		current, err = processor.PostProcessBeforeInitialization(current, name)
+		measured()
 		if err != nil {
 			return nil, pkgerrors.Wrapf(err, "component post processor %s apply post process before initialization", reflectx.Id(processor))
 		}
@@ -197,7 +202,9 @@ func (f *PostProcessorRegistrationDelegate) applyPostProcessAfterInitialization(
 	)
 	var current any
 	for _, processor := range f.componentPostProcessors {
+		measured := f.timings.measureProcessor(name, processor)
 		current, err = processor.PostProcessAfterInitialization(result, name)
+		measured()
 		if err != nil {
 			return nil, pkgerrors.Wrapf(err, "component post processor %s apply post process after initialization", reflectx.Id(processor))
 		}
@@ -236,7 +243,9 @@ func (f *PostProcessorRegistrationDelegate) applyPostProcessBeforeInstantiation(
 	var err error
 	for _, processor := range f.componentPostProcessors {
 		if ipb, ok := processor.(container.InstantiationAwareComponentPostProcessor); ok {
+			measured := f.timings.measureProcessor(name, ipb)
 			component, err = ipb.PostProcessBeforeInstantiation(meta, name)
+			measured()
 			if err != nil {
 				return nil, pkgerrors.Wrapf(err, "apply %T.PostProcessBeforeInstantiation() for component '%s'", ipb, name)
 			}
@@ -251,12 +260,16 @@ func (f *PostProcessorRegistrationDelegate) applyPostProcessBeforeInstantiation(
 func (f *PostProcessorRegistrationDelegate) ResolveAfterInstantiation(meta *component_definition.Meta, name string) error {
 	for _, processor := range f.componentPostProcessors {
 		if ipb, ok := processor.(container.InstantiationAwareComponentPostProcessor); ok {
+			measured := f.timings.measureProcessor(name, ipb)
 			ok, err := ipb.PostProcessAfterInstantiation(meta.Raw, name)
+			measured()
 			if err != nil {
 				return pkgerrors.Wrapf(err, "apply %T.PostProcessAfterInstantiation() for component '%s'", ipb, name)
 			}
 			if ok {
+				measured := f.timings.measureProcessor(name, ipb)
 				_, err := ipb.PostProcessProperties(meta.GetAllProperties(), meta.Raw, name)
+				measured()
 				if err != nil {
 					return pkgerrors.Wrapf(err, "apply %T.PostProcessProperties() for component '%s'", ipb, name)
 				}
@@ -269,6 +282,39 @@ func (f *PostProcessorRegistrationDelegate) ResolveAfterInstantiation(meta *comp
 	return nil
 }
 
+// ResolveProperties applies PostProcessProperties of the instantiation aware post processors on properties
+// not bound to an instantiated component, e.g. the configuration parameters of a constructor.
+func (f *PostProcessorRegistrationDelegate) ResolveProperties(properties []*component_definition.Property, component any, name string) error {
+	for _, processor := range f.componentPostProcessors {
+		if ipb, ok := processor.(container.InstantiationAwareComponentPostProcessor); ok {
+			if _, err := ipb.PostProcessProperties(properties, component, name); err != nil {
+				return pkgerrors.Wrapf(err, "apply %T.PostProcessProperties() for component '%s'", ipb, name)
+			}
+		}
+	}
+	return nil
+}
+
+// propertyMatcher is implemented by the built-in post processors whose PostProcessProperties only matches
+// the properties (their injects, configurations and tag values) without touching the component.
+type propertyMatcher interface {
+	container.InstantiationAwareComponentPostProcessor
+	MatchesProperties()
+}
+
+// MatchProperties applies PostProcessProperties of the property matchers only,
+// it probes the wiring of properties without the side effects of the other post processors.
+func (f *PostProcessorRegistrationDelegate) MatchProperties(properties []*component_definition.Property, component any, name string) error {
+	for _, processor := range f.componentPostProcessors {
+		if matcher, ok := processor.(propertyMatcher); ok {
+			if _, err := matcher.PostProcessProperties(properties, component, name); err != nil {
+				return pkgerrors.Wrapf(err, "apply %T.PostProcessProperties() for component '%s'", matcher, name)
+			}
+		}
+	}
+	return nil
+}
+
 func (f *PostProcessorRegistrationDelegate) GetEarlyBeanReference(name string, m any) (any, error) {
 	var exposedComponent = m
 	var err error
@@ -276,7 +322,9 @@ func (f *PostProcessorRegistrationDelegate) GetEarlyBeanReference(name string, m
 		for _, processor := range f.componentPostProcessors {
 			if ibp, ok := processor.(container.SmartInstantiationAwareBeanPostProcessor); ok {
 				f.emitEvent("refresh", "get_early_bean_reference", name, reflectx.Id(ibp), nil)
+				measured := f.timings.measureProcessor(name, ibp)
 				exposedComponent, err = ibp.GetEarlyBeanReference(exposedComponent, name)
+				measured()
 				if err != nil {
 					return nil, pkgerrors.Wrapf(err, "apply %T.GetEarlyBeanReference() for component '%s'", ibp, name)
 				}
@@ -285,3 +333,19 @@ func (f *PostProcessorRegistrationDelegate) GetEarlyBeanReference(name string, m
 	}
 	return exposedComponent, nil
 }
+
+func (f *PostProcessorRegistrationDelegate) ApplyBeforeDestruction(name string, component any) error {
+	if !f.hasDestructionAwareComponentPostProcessor {
+		return nil
+	}
+	var errs []error
+	for _, processor := range f.componentPostProcessors {
+		if dp, ok := processor.(container.DestructionAwareComponentPostProcessor); ok && dp.RequireDestruction(component) {
+			f.emitEvent("destroy", "before_destruction", name, reflectx.Id(dp), nil)
+			if err := dp.PostProcessBeforeDestruction(component, name); err != nil {
+				errs = append(errs, pkgerrors.Wrapf(err, "apply %T.PostProcessBeforeDestruction() for component '%s'", dp, name))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,151 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container"
+)
+
+// SetAggregateErrors makes Refresh continue past failed components instead of returning the first error,
+// the components depending on a failed one are skipped, all of them are returned as a *container.RefreshError.
+func (f *defaultFactory) SetAggregateErrors(aggregate bool) {
+	if aggregate {
+		f.failures = &refreshFailures{failed: make(map[string]bool)}
+	} else {
+		f.failures = nil
+	}
+}
+
+// refreshFailures collects the failed and skipped singletons of a refresh, it is shared by the refresh workers
+type refreshFailures struct {
+	mu      sync.Mutex
+	failed  map[string]bool
+	errors  []container.ComponentError
+	skipped []container.SkippedComponent
+}
+
+func (r *refreshFailures) has(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failed[name]
+}
+
+func (r *refreshFailures) fail(name string, errs ...container.ComponentError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed[name] = true
+	r.errors = append(r.errors, errs...)
+}
+
+func (r *refreshFailures) skip(skipped container.SkippedComponent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed[skipped.Component] = true
+	r.skipped = append(r.skipped, skipped)
+}
+
+func (r *refreshFailures) err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.errors) == 0 && len(r.skipped) == 0 {
+		return nil
+	}
+	return &container.RefreshError{
+		Failures: append([]container.ComponentError(nil), r.errors...),
+		Skipped:  append([]container.SkippedComponent(nil), r.skipped...),
+	}
+}
+
+// dependencyFailedError is returned instead of creating a singleton which failed or was skipped before
+type dependencyFailedError struct {
+	name string
+	err  error
+}
+
+func (e *dependencyFailedError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("component '%s' failed before", e.name)
+	}
+	return e.err.Error()
+}
+
+func (e *dependencyFailedError) Unwrap() error {
+	return e.err
+}
+
+// injectionError locates the field or the constructor parameter whose injection failed,
+// it keeps the message of the wrapped error.
+type injectionError struct {
+	field string
+	tag   string
+	err   error
+}
+
+func (e *injectionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *injectionError) Unwrap() error {
+	return e.err
+}
+
+func propertyTag(prop *component_definition.Property) string {
+	return fmt.Sprintf(`%s:"%s"`, prop.Tag, prop.TagStr)
+}
+
+// recordFailure records the failed creation of the singleton name, a singleton failing on a failed or skipped
+// dependency is skipped.
+func (f *defaultFactory) recordFailure(ctx context.Context, name string, err error) error {
+	var depErr *dependencyFailedError
+	if errors.As(err, &depErr) {
+		skipped := container.SkippedComponent{Component: name, Dependency: depErr.name}
+		var injectErr *injectionError
+		if errors.As(err, &injectErr) {
+			skipped.Field = injectErr.field
+		}
+		f.logger().Debugf("skip component '%s', its dependency '%s' failed", name, depErr.name)
+		f.failures.skip(skipped)
+	} else {
+		f.logger().Debugf("component '%s' failed: %v", name, err)
+		f.failures.fail(name, f.componentErrors(ctx, name, err)...)
+	}
+	return &dependencyFailedError{name: name, err: err}
+}
+
+// componentErrors locates the failure of a component, the failed injection if it is known,
+// otherwise the properties failing on their own, otherwise the whole component.
+func (f *defaultFactory) componentErrors(ctx context.Context, name string, err error) []container.ComponentError {
+	chain := append(resolveStackOf(ctx), name)
+	var injectErr *injectionError
+	if errors.As(err, &injectErr) {
+		return []container.ComponentError{{
+			Component: name,
+			Field:     injectErr.field,
+			Tag:       injectErr.tag,
+			Reason:    err.Error(),
+			Chain:     chain,
+		}}
+	}
+	var errs []container.ComponentError
+	if meta := f.definitionRegistry.GetMetaByName(name); meta != nil {
+		for _, prop := range meta.GetAllProperties() {
+			if problem, ok := f.validateProperty(name, meta, prop); ok {
+				errs = append(errs, container.ComponentError{
+					Component: name,
+					Field:     problem.Field,
+					Tag:       propertyTag(prop),
+					Reason:    problem.Reason,
+					Chain:     chain,
+				})
+			}
+		}
+	}
+	if len(errs) == 0 {
+		errs = append(errs, container.ComponentError{Component: name, Reason: err.Error(), Chain: chain})
+	}
+	return errs
+}
@@ -0,0 +1,197 @@
+package factory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/util/reflectx"
+)
+
+// SetMeasureStartup makes the factory measure the time spent creating each component and in each post processor,
+// nothing is measured by default.
+func (f *defaultFactory) SetMeasureStartup(measure bool) {
+	if measure {
+		f.timings = newStartupTimings()
+	} else {
+		f.timings = nil
+	}
+	f.postProcessorRegistrationDelegate.timings = f.timings
+}
+
+// GetStartupReport returns the timing of the components created so far, it is empty unless SetMeasureStartup is enabled
+func (f *defaultFactory) GetStartupReport() *container.StartupReport {
+	return f.timings.report()
+}
+
+// startupTimings accumulates the timing of the component creations, it is safe for concurrent use
+type startupTimings struct {
+	mu         sync.Mutex
+	components map[string]*container.ComponentTiming
+	processors map[string]*container.PostProcessorTiming
+}
+
+func newStartupTimings() *startupTimings {
+	return &startupTimings{
+		components: make(map[string]*container.ComponentTiming),
+		processors: make(map[string]*container.PostProcessorTiming),
+	}
+}
+
+func (t *startupTimings) component(name string, record func(timing *container.ComponentTiming)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	record(t.componentTiming(name))
+}
+
+func (t *startupTimings) componentTiming(name string) *container.ComponentTiming {
+	timing, ok := t.components[name]
+	if !ok {
+		timing = &container.ComponentTiming{Name: name}
+		t.components[name] = timing
+	}
+	return timing
+}
+
+// processor records a call of processor for the component name
+func (t *startupTimings) processor(name, processor string, elapsed time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timing, ok := t.processors[processor]
+	if !ok {
+		timing = &container.PostProcessorTiming{Name: processor}
+		t.processors[processor] = timing
+	}
+	timing.Calls++
+	timing.Total += elapsed
+	t.componentTiming(name).PostProcessors += elapsed
+}
+
+// measureProcessor starts measuring a call of processor for the component name, the returned function records it
+func (t *startupTimings) measureProcessor(name string, processor any) func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		t.processor(name, reflectx.Id(processor), time.Since(start))
+	}
+}
+
+// measureInit starts measuring the init methods of the component name, the returned function records it
+func (t *startupTimings) measureInit(name string) func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		t.component(name, func(timing *container.ComponentTiming) {
+			timing.Init += elapsed
+		})
+	}
+}
+
+func (t *startupTimings) report() *container.StartupReport {
+	report := &container.StartupReport{}
+	if t == nil {
+		return report
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, timing := range t.components {
+		if timing.Creations != 0 {
+			report.Components = append(report.Components, *timing)
+		}
+	}
+	for _, timing := range t.processors {
+		report.PostProcessors = append(report.PostProcessors, *timing)
+	}
+	slices.SortFunc(report.Components, func(a, b container.ComponentTiming) int {
+		return compareTiming(a.Total, b.Total, a.Name, b.Name)
+	})
+	slices.SortFunc(report.PostProcessors, func(a, b container.PostProcessorTiming) int {
+		return compareTiming(a.Total, b.Total, a.Name, b.Name)
+	})
+	return report
+}
+
+// compareTiming orders the slowest first, then by name
+func compareTiming(a, b time.Duration, aName, bName string) int {
+	if c := cmp.Compare(b, a); c != 0 {
+		return c
+	}
+	return cmp.Compare(aName, bName)
+}
+
+type creationFrameKey struct{}
+
+// creationFrame accumulates the time spent creating the dependencies of the component being created
+type creationFrame struct {
+	nested time.Duration
+}
+
+// measureCreation starts measuring the creation of the component name,
+// the returned function records it and accounts it to the creation of the dependent component.
+func (f *defaultFactory) measureCreation(ctx context.Context, name string) (context.Context, func()) {
+	if f.timings == nil {
+		return ctx, func() {}
+	}
+	parent, _ := ctx.Value(creationFrameKey{}).(*creationFrame)
+	frame := &creationFrame{}
+	start := time.Now()
+	return context.WithValue(ctx, creationFrameKey{}, frame), func() {
+		elapsed := time.Since(start)
+		if parent != nil {
+			parent.nested += elapsed
+		}
+		f.timings.component(name, func(timing *container.ComponentTiming) {
+			timing.Total += elapsed - frame.nested
+			timing.Creations++
+		})
+	}
+}
+
+// measurePhase starts measuring a phase of the creation of the component name,
+// the returned function records the time elapsed into the phase selected by phase,
+// excluding the creation of dependencies and the post processors called meanwhile.
+func (f *defaultFactory) measurePhase(ctx context.Context, name string, phase func(timing *container.ComponentTiming) *time.Duration) func() {
+	if f.timings == nil {
+		return func() {}
+	}
+	frame, _ := ctx.Value(creationFrameKey{}).(*creationFrame)
+	var nested, processors time.Duration
+	if frame != nil {
+		nested = frame.nested
+	}
+	f.timings.component(name, func(timing *container.ComponentTiming) {
+		processors = timing.PostProcessors
+	})
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		if frame != nil {
+			elapsed -= frame.nested - nested
+		}
+		f.timings.component(name, func(timing *container.ComponentTiming) {
+			*phase(timing) += elapsed - (timing.PostProcessors - processors)
+		})
+	}
+}
+
+func instantiationPhase(timing *container.ComponentTiming) *time.Duration {
+	return &timing.Instantiation
+}
+
+func populationPhase(timing *container.ComponentTiming) *time.Duration {
+	return &timing.Population
+}
@@ -0,0 +1,194 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/definition"
+	"github.com/samber/lo"
+)
+
+// ValidateWiring checks the wiring of the prepared components without creating them.
+// Every property is matched on its own through the property matchers, and every constructor parameter
+// is matched like invokeConstructor does, so all the problems are reported at once instead of the first one.
+func (f *defaultFactory) ValidateWiring() []container.WiringProblem {
+	var names []string
+	for _, meta := range f.definitionRegistry.GetMetas() {
+		if cc, ok := meta.Raw.(definition.ConditionalComponent); ok && !cc.Condition(f.newConditionContext()) {
+			continue
+		}
+		names = append(names, meta.Name())
+	}
+	slices.Sort(names)
+
+	var problems []container.WiringProblem
+	for _, name := range names {
+		meta := f.definitionRegistry.GetMetaByName(name)
+		for _, prop := range meta.GetAllProperties() {
+			if problem, ok := f.validateProperty(name, meta, prop); ok {
+				problems = append(problems, problem)
+			}
+		}
+		if constructor, ok := f.constructorOf(name); ok {
+			for i := 0; i < constructor.Type.NumIn(); i++ {
+				if problem, ok := f.validateConstructorParam(name, i, constructor.Type.In(i), constructor.Param(i)); ok {
+					problems = append(problems, problem)
+				}
+			}
+		}
+	}
+	return append(problems, f.validateCycles(names)...)
+}
+
+func (f *defaultFactory) validateProperty(name string, meta *component_definition.Meta, prop *component_definition.Property) (container.WiringProblem, bool) {
+	probe := probeProperties([]*component_definition.Property{prop})[0]
+	err := f.postProcessorRegistrationDelegate.MatchProperties([]*component_definition.Property{probe}, meta.Raw, name)
+	if err == nil {
+		return container.WiringProblem{}, false
+	}
+	problem := container.WiringProblem{
+		Kind:      container.ProblemInvalid,
+		Component: name,
+		Field:     prop.StructField.Name,
+		Reason:    err.Error(),
+	}
+	var (
+		noSuchErr    *container.NoSuchComponentError
+		ambiguousErr *container.AmbiguousComponentError
+		missingErr   *container.MissingConfigError
+	)
+	switch {
+	case errors.As(err, &noSuchErr):
+		problem.Kind = container.ProblemUnresolved
+	case errors.As(err, &ambiguousErr):
+		problem.Kind = container.ProblemAmbiguous
+	case errors.As(err, &missingErr):
+		problem.Kind = container.ProblemMissingConfig
+	}
+	return problem, true
+}
+
+func (f *defaultFactory) validateConstructorParam(name string, index int, paramType reflect.Type, param *component_definition.ConstructorParam) (container.WiringProblem, bool) {
+	problem := container.WiringProblem{
+		Component: name,
+		Field:     fmt.Sprintf("param[%d]", index),
+	}
+	if param.IsConfiguration() {
+		if _, err := f.resolveConstructorParamValue(name, paramType, param); err != nil {
+			problem.Kind, problem.Reason = container.ProblemMissingConfig, err.Error()
+			return problem, true
+		}
+		return problem, false
+	}
+	candidates, err := f.constructorParamCandidates(paramType, param)
+	if err != nil {
+		problem.Kind, problem.Reason = container.ProblemInvalid, err.Error()
+		return problem, true
+	}
+	if paramType.Kind() == reflect.Slice {
+		return problem, false
+	}
+	switch {
+	case len(candidates) == 0:
+		if !param.IsRequired() {
+			return problem, false
+		}
+		if paramType.Kind() == reflect.Ptr && !param.IsSelective() {
+			if resolved, err := f.resolveConfigurationProperties(paramType); err != nil {
+				problem.Kind, problem.Reason = container.ProblemMissingConfig, err.Error()
+				return problem, true
+			} else if resolved.IsValid() {
+				return problem, false
+			}
+		}
+		problem.Kind = container.ProblemUnresolved
+		problem.Reason = fmt.Sprintf("no component found for type %s", paramType)
+		if param.IsSelective() {
+			problem.Reason = fmt.Sprintf("no component found for type %s matching %s", paramType, param)
+		}
+		return problem, true
+	case len(candidates) > 1 && f.strictWiring:
+		if _, err := component_definition.SelectUniqueCandidate(candidates); err != nil {
+			problem.Kind = container.ProblemAmbiguous
+			problem.Reason = fmt.Sprintf("type %s is ambiguous: %v", paramType, err)
+			return problem, true
+		}
+	}
+	return problem, false
+}
+
+// validateCycles reports the circular references including a constructor parameter,
+// a component created by its constructor can not be exposed early to resolve them.
+// All the circular references are reported when they are disallowed.
+func (f *defaultFactory) validateCycles(names []string) []container.WiringProblem {
+	graph := make(map[string][]string, len(names))
+	constructorDeps := make(map[string][]string, len(names))
+	for _, name := range names {
+		constructorDeps[name] = f.constructorDependencies(name)
+		graph[name] = append(f.fieldDependencies(name, f.definitionRegistry.GetMetaByName(name)), constructorDeps[name]...)
+	}
+	var problems []container.WiringProblem
+	for _, group := range stronglyConnectedComponents(names, graph) {
+		inGroup := lo.SliceToMap(group, func(name string) (string, bool) { return name, true })
+		reason := "circular reference through constructor parameters"
+		from, to, found := cycleEdge(group, constructorDeps, inGroup)
+		if !found && !f.allowCircularReferences {
+			reason = "circular reference while circular references are disallowed"
+			from, to, found = cycleEdge(group, graph, inGroup)
+		}
+		if !found {
+			continue
+		}
+		cycle := append([]string{from}, cyclePath(graph, inGroup, to, from)...)
+		problems = append(problems, container.WiringProblem{
+			Kind:      container.ProblemCycle,
+			Component: from,
+			Reason:    fmt.Sprintf("%s: %s", reason, formatCycle(cycle)),
+		})
+	}
+	return problems
+}
+
+// cycleEdge returns the first edge of deps inside a strongly connected group
+func cycleEdge(group []string, deps map[string][]string, inGroup map[string]bool) (from, to string, found bool) {
+	for _, from := range group {
+		if to, found := lo.Find(deps[from], func(dep string) bool { return inGroup[dep] }); found {
+			return from, to, true
+		}
+	}
+	return "", "", false
+}
+
+// cyclePath returns the shortest path from start to end inside a strongly connected group
+func cyclePath(graph map[string][]string, inGroup map[string]bool, start, end string) []string {
+	previous := map[string]string{start: ""}
+	queue := []string{start}
+	for len(queue) != 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if name == end {
+			var path []string
+			for n := end; n != ""; n = previous[n] {
+				path = append(path, n)
+			}
+			slices.Reverse(path)
+			return path
+		}
+		for _, dep := range graph[name] {
+			if _, visited := previous[dep]; !visited && inGroup[dep] {
+				previous[dep] = name
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return []string{start, end}
+}
+
+func formatCycle(cycle []string) string {
+	return strings.Join(cycle, " -> ")
+}
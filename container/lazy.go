@@ -0,0 +1,68 @@
+package container
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy is a field type injected with a handle of a component which is created on the first Get, instead of the component itself.
+// Unlike Provider, the resolved component is kept and returned by the later calls,
+// so a definition.LazyInit component injected by Lazy is only created once it is used.
+//
+//	type Handler struct {
+//		Report container.Lazy[*ReportService] `wire:""`
+//	}
+type Lazy[T any] struct {
+	provider Provider[T]
+	mu       sync.Mutex
+	done     atomic.Bool
+	value    T
+}
+
+func (l *Lazy[T]) BindProvider(factory Factory, name string) {
+	l.provider.BindProvider(factory, name)
+}
+
+func (l *Lazy[T]) ProvidedType() reflect.Type {
+	return l.provider.ProvidedType()
+}
+
+// Name returns the name of the component
+func (l *Lazy[T]) Name() string {
+	return l.provider.Name()
+}
+
+// Get creates the component on the first call and returns the same component afterwards, it is safe for concurrent use.
+// A creation error is returned to the caller and the next call tries again.
+func (l *Lazy[T]) Get() (T, error) {
+	if l.done.Load() {
+		return l.value, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.done.Load() {
+		return l.value, nil
+	}
+	t, err := l.provider.Get()
+	if err != nil {
+		return t, err
+	}
+	l.value = t
+	l.done.Store(true)
+	return t, nil
+}
+
+// MustGet is like Get but panics if the component can not be created
+func (l *Lazy[T]) MustGet() T {
+	t, err := l.Get()
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Resolved reports whether the component has been created by Get
+func (l *Lazy[T]) Resolved() bool {
+	return l.done.Load()
+}
@@ -33,19 +33,33 @@ func And(opts ...Option) Option {
 
 func Type(typ reflect.Type) Option {
 	return func(m *component_definition.Meta) bool {
-		return m.Value.Type() == typ
+		return m.ExposedType() == typ
 	}
 }
 
 func InterfaceType(typ reflect.Type) Option {
 	return func(m *component_definition.Meta) bool {
-		return m.Value.Type().Implements(typ)
+		return m.ExposedType().Implements(typ)
 	}
 }
 
 func Interface(a any) Option {
 	return func(m *component_definition.Meta) bool {
-		return reflectx.IsTypeImplement(m.Value.Type(), a)
+		return reflectx.IsTypeImplement(m.ExposedType(), a)
+	}
+}
+
+// Qualifier matches components qualified by one of the qualifiers, see component_definition.Meta.Qualifier
+func Qualifier(qualifiers ...string) Option {
+	return func(m *component_definition.Meta) bool {
+		if q, ok := m.Qualifier(); ok {
+			for _, qualifier := range qualifiers {
+				if q == qualifier {
+					return true
+				}
+			}
+		}
+		return false
 	}
 }
 
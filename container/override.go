@@ -0,0 +1,31 @@
+package container
+
+// OverridePolicy decides how a SingletonRegistry handles a component registered with a name already in use
+type OverridePolicy string
+
+const (
+	// OverridePanic panics on the duplicated registration, it is the default policy
+	OverridePanic OverridePolicy = "panic"
+	// OverrideError keeps the first registration and fails the application before components are prepared
+	OverrideError OverridePolicy = "error"
+	// OverrideLastWins replaces the registered component by the latest one
+	OverrideLastWins OverridePolicy = "last-wins"
+	// OverrideFirstWins keeps the registered component and discards the latest one
+	OverrideFirstWins OverridePolicy = "first-wins"
+)
+
+// ComponentOverride records a registration conflicting on Name with an existing one
+type ComponentOverride struct {
+	Name     string
+	Previous any
+	Current  any
+	// Policy is the policy the conflict was resolved by, empty for an explicit replacement
+	Policy OverridePolicy
+	// Replaced reports whether Current took the place of Previous
+	Replaced bool
+}
+
+// IsExplicit reports whether the override was requested by SingletonRegistry.ReplaceSingleton
+func (o ComponentOverride) IsExplicit() bool {
+	return o.Policy == ""
+}
@@ -39,6 +39,8 @@ func (c *configQuoteAwarePostProcessors) Order() int {
 	return PriorityOrderPropertyConfigQuoteAware
 }
 
+func (c *configQuoteAwarePostProcessors) MatchesProperties() {}
+
 func (c *configQuoteAwarePostProcessors) PostProcessProperties(properties []*component_definition.Property, component any, componentName string) ([]*component_definition.Property, error) {
 	logger := syslog.Pref("ConfigQuoteAwarePostProcessor")
 	for _, prop := range properties {
@@ -4,6 +4,7 @@ import (
 	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/container"
 	"github.com/go-kid/ioc/definition"
+	"github.com/samber/lo"
 	"reflect"
 )
 
@@ -37,27 +38,37 @@ func (d *dependencyAwarePostProcessors) Order() int {
 	return OrderDependencyAware
 }
 
+func (d *dependencyAwarePostProcessors) MatchesProperties() {}
+
 func (d *dependencyAwarePostProcessors) PostProcessProperties(properties []*component_definition.Property, component any, componentName string) ([]*component_definition.Property, error) {
 	for _, prop := range properties {
 		if prop.Tag != definition.InjectTag {
 			continue
 		}
+		injectType := prop.Type
+		if binder, ok := container.AsProviderBinder(prop.Value); ok {
+			injectType = binder.ProvidedType()
+		}
 		//aware by type
 		if prop.TagVal == "" {
 			var typeOption container.Option
-			if p, ok := isActualKind(prop.Type, reflect.Pointer); ok {
+			if p, ok := isActualKind(injectType, reflect.Pointer); ok {
 				typeOption = container.Type(p)
-			} else if p, ok = isActualKind(prop.Type, reflect.Interface); ok {
+			} else if p, ok = isActualKind(injectType, reflect.Interface); ok {
 				typeOption = container.InterfaceType(p)
 			} else {
 				continue
 			}
 			dm := d.Registry.GetMetas(typeOption)
+			if prop.Type.Kind() == reflect.Slice && !prop.Holder.Meta.IsCustomScope() {
+				//components of custom scope are not resolvable while creating singletons, skip them from collections
+				dm = lo.Filter(dm, func(m *component_definition.Meta, _ int) bool { return !m.IsCustomScope() })
+			}
 			prop.Injects = append(prop.Injects, dm...)
 			continue
 		}
 		//aware by name
-		if prop.TagVal != "" && (prop.Type.Kind() == reflect.Ptr || prop.Type.Kind() == reflect.Interface) {
+		if prop.TagVal != "" && (injectType.Kind() == reflect.Ptr || injectType.Kind() == reflect.Interface) {
 			dm := d.Registry.GetMetaByName(prop.TagVal)
 			prop.Injects = append(prop.Injects, dm)
 		}
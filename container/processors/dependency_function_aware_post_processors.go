@@ -38,6 +38,8 @@ func (d *dependencyFunctionAwarePostProcessors) Order() int {
 	return OrderDependencyAware
 }
 
+func (d *dependencyFunctionAwarePostProcessors) MatchesProperties() {}
+
 func (d *dependencyFunctionAwarePostProcessors) PostProcessProperties(properties []*component_definition.Property, component any, componentName string) ([]*component_definition.Property, error) {
 	for _, prop := range properties {
 		if prop.Tag != definition.FuncTag {
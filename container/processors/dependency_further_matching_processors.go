@@ -12,12 +12,19 @@ import (
 type dependencyFurtherMatchingPostProcessors struct {
 	DefaultInstantiationAwareComponentPostProcessor
 	definition.LazyInitComponent
+	strict bool
 }
 
 func NewDependencyFurtherMatchingProcessors() container.InstantiationAwareComponentPostProcessor {
 	return &dependencyFurtherMatchingPostProcessors{}
 }
 
+// NewStrictDependencyFurtherMatchingProcessors fails single-valued injection
+// when more than one candidate remains after Qualifier and WirePrimary filtering
+func NewStrictDependencyFurtherMatchingProcessors() container.InstantiationAwareComponentPostProcessor {
+	return &dependencyFurtherMatchingPostProcessors{strict: true}
+}
+
 func (d *dependencyFurtherMatchingPostProcessors) PostProcessAfterInstantiation(component any, componentName string) (bool, error) {
 	return true, nil
 }
@@ -26,18 +33,21 @@ func (d *dependencyFurtherMatchingPostProcessors) Order() int {
 	return OrderDependencyFurtherMatching
 }
 
+func (d *dependencyFurtherMatchingPostProcessors) MatchesProperties() {}
+
 func (d *dependencyFurtherMatchingPostProcessors) PostProcessProperties(properties []*component_definition.Property, component any, componentName string) ([]*component_definition.Property, error) {
 	for _, prop := range properties {
 		if prop.PropertyType != component_definition.PropertyTypeComponent {
 			continue
 		}
-		dependencies, err := filterDependencies(prop, prop.Injects)
+		dependencies, err := filterDependencies(prop, prop.Injects, d.strict)
 		if err != nil {
 			if len(dependencies) == 0 {
 				if prop.IsRequired() {
-					return nil, errors.WithMessagef(err, "field '%s' is required but not found any components", prop.String())
+					return nil, errors.WithMessage(err, "required field not resolved")
 				}
-				return nil, nil
+				prop.Injects = nil
+				continue
 			}
 			return nil, err
 		}
@@ -46,23 +56,35 @@ func (d *dependencyFurtherMatchingPostProcessors) PostProcessProperties(properti
 	return nil, nil
 }
 
-func filterDependencies(n *component_definition.Property, metas []*component_definition.Meta) ([]*component_definition.Meta, error) {
+func filterDependencies(n *component_definition.Property, metas []*component_definition.Meta, strict bool) ([]*component_definition.Meta, error) {
 	result := lo.Filter(metas, func(m *component_definition.Meta, _ int) bool { return m != nil })
 	if len(result) == 0 {
-		return nil, errors.Errorf("inject '%s' not found available components", n)
+		return nil, &container.NoSuchComponentError{InjectionPoint: component_definition.PointOf(n), Type: n.Type}
 	}
 	if qualifierName, isQualifier := n.Args().Find(component_definition.ArgQualifier); isQualifier {
 		result = lo.Filter(result, func(m *component_definition.Meta, _ int) bool {
-			qualifier, ok := m.Raw.(definition.WireQualifier)
-			return ok && n.Args().Has(component_definition.ArgQualifier, qualifier.Qualifier())
+			qualifier, ok := m.Qualifier()
+			return ok && n.Args().Has(component_definition.ArgQualifier, qualifier)
 		})
 		if len(result) == 0 {
-			return nil, errors.Errorf("inject '%s' matching qualifier '%s' not found available components", n, qualifierName)
+			return nil, &container.NoSuchComponentError{InjectionPoint: component_definition.PointOf(n), Type: n.Type, Qualifiers: qualifierName}
 		}
 	}
 
 	if len(result) > 1 && n.Type.Kind() != reflect.Slice && n.Type.Kind() != reflect.Array {
-		result = []*component_definition.Meta{component_definition.SelectBestCandidate(result)}
+		if strict {
+			selected, err := component_definition.SelectUniqueCandidate(result)
+			if err != nil {
+				var ambiguous *container.AmbiguousComponentError
+				if errors.As(err, &ambiguous) {
+					ambiguous.InjectionPoint, ambiguous.Type = component_definition.PointOf(n), n.Type
+				}
+				return result, errors.WithMessage(err, "strict wiring mode")
+			}
+			result = []*component_definition.Meta{selected}
+		} else {
+			result = []*component_definition.Meta{component_definition.SelectBestCandidate(result)}
+		}
 	}
 	return result, nil
 }
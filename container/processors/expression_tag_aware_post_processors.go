@@ -32,6 +32,8 @@ func (c *expressionTagAwarePostProcessors) Order() int {
 	return PriorityOrderPropertyExpressionTagAware
 }
 
+func (c *expressionTagAwarePostProcessors) MatchesProperties() {}
+
 func (c *expressionTagAwarePostProcessors) PostProcessProperties(properties []*component_definition.Property, component any, componentName string) ([]*component_definition.Property, error) {
 	for _, prop := range properties {
 		if !c.el.MatchString(prop.TagVal) {
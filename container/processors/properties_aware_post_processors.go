@@ -45,6 +45,8 @@ func (c *propertiesAwarePostProcessors) Order() int {
 	return PriorityOrderPopulateProperties
 }
 
+func (c *propertiesAwarePostProcessors) MatchesProperties() {}
+
 func (c *propertiesAwarePostProcessors) PostProcessProperties(properties []*component_definition.Property, component any, componentName string) ([]*component_definition.Property, error) {
 	for _, prop := range properties {
 		if prop.Tag != definition.PrefixTag {
@@ -55,7 +57,7 @@ func (c *propertiesAwarePostProcessors) PostProcessProperties(properties []*comp
 		prop.SetConfiguration(prop.TagVal, configValue)
 		if configValue == nil {
 			if prop.IsRequired() {
-				return nil, errors.Errorf("config value on '%s' is required", prop)
+				return nil, &container.MissingConfigError{InjectionPoint: component_definition.PointOf(prop), Keys: []string{prop.TagVal}}
 			}
 			continue
 		}
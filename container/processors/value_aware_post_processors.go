@@ -45,6 +45,8 @@ func (c *valueAwarePostProcessors) Order() int {
 	return PriorityOrderPopulateProperties
 }
 
+func (c *valueAwarePostProcessors) MatchesProperties() {}
+
 func (c *valueAwarePostProcessors) PostProcessProperties(properties []*component_definition.Property, component any, componentName string) ([]*component_definition.Property, error) {
 	for _, prop := range properties {
 		if prop.Tag != definition.ValueTag {
@@ -52,6 +54,9 @@ func (c *valueAwarePostProcessors) PostProcessProperties(properties []*component
 		}
 		if prop.TagVal == "" {
 			if prop.IsRequired() {
+				if keys := prop.MissingConfigurations(); len(keys) != 0 {
+					return nil, &container.MissingConfigError{InjectionPoint: component_definition.PointOf(prop), Keys: keys}
+				}
 				return nil, errors.Errorf("value on '%s' is required", prop)
 			}
 			continue
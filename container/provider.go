@@ -0,0 +1,92 @@
+package container
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Provider is a field type injected with a lazy handle of a component instead of the component itself.
+// The component is resolved from the Factory on every Get, so singletons can safely depend on
+// prototype or custom scoped components.
+//
+//	type Handler struct {
+//		Request container.Provider[*RequestContext] `wire:""`
+//	}
+type Provider[T any] struct {
+	factory Factory
+	name    string
+}
+
+// ProviderBinder is implemented by *Provider, the factory binds the matched component to the field by it.
+type ProviderBinder interface {
+	ProvidedType() reflect.Type
+	BindProvider(factory Factory, name string)
+}
+
+// AsProviderBinder returns the ProviderBinder of an addressable field value, if the field is a Provider
+func AsProviderBinder(v reflect.Value) (ProviderBinder, bool) {
+	if !v.IsValid() || !v.CanAddr() || !v.Addr().CanInterface() {
+		return nil, false
+	}
+	binder, ok := v.Addr().Interface().(ProviderBinder)
+	return binder, ok
+}
+
+func (p *Provider[T]) BindProvider(factory Factory, name string) {
+	p.factory = factory
+	p.name = name
+}
+
+// Name returns the name of the provided component
+func (p Provider[T]) Name() string {
+	return p.name
+}
+
+// Get resolves the component with the application context
+func (p Provider[T]) Get() (T, error) {
+	return p.resolve(func() (any, error) {
+		return p.factory.GetComponentByName(p.name)
+	})
+}
+
+// GetWithContext resolves the component with ctx, custom scoped components are looked up in the scope bound to ctx
+func (p Provider[T]) GetWithContext(ctx context.Context) (T, error) {
+	return p.resolve(func() (any, error) {
+		scoped, ok := p.factory.(ScopedFactory)
+		if !ok {
+			return nil, errors.Errorf("factory %T does not support custom scopes", p.factory)
+		}
+		return scoped.GetComponentByNameWithContext(ctx, p.name)
+	})
+}
+
+// MustGet is like Get but panics if the component can not be resolved
+func (p Provider[T]) MustGet() T {
+	t, err := p.Get()
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (p Provider[T]) resolve(get func() (any, error)) (T, error) {
+	var zero T
+	if p.factory == nil {
+		return zero, errors.Errorf("provider of %s is not bound to any component", p.ProvidedType())
+	}
+	component, err := get()
+	if err != nil {
+		return zero, errors.WithMessagef(err, "provider get component '%s'", p.name)
+	}
+	t, ok := component.(T)
+	if !ok {
+		return zero, errors.Errorf("provider get component '%s': %T is not %s", p.name, component, p.ProvidedType())
+	}
+	return t, nil
+}
+
+func (p Provider[T]) ProvidedType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
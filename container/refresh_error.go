@@ -0,0 +1,71 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ComponentError is the failure of a component during refresh
+type ComponentError struct {
+	Component string `json:"component"`
+	// Field is the field or the constructor parameter (e.g. "param[0]") that failed, empty if unknown
+	Field string `json:"field,omitempty"`
+	// Tag is the tag of Field, e.g. `wire:"db"`
+	Tag    string `json:"tag,omitempty"`
+	Reason string `json:"reason"`
+	// Chain is the dependency chain from the refreshed component to Component
+	Chain []string `json:"chain,omitempty"`
+}
+
+func (e ComponentError) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "component '%s'", e.Component)
+	if e.Field != "" {
+		fmt.Fprintf(&sb, " %s", e.Field)
+	}
+	if e.Tag != "" {
+		fmt.Fprintf(&sb, " `%s`", e.Tag)
+	}
+	fmt.Fprintf(&sb, ": %s", strings.ReplaceAll(e.Reason, "\n", "\n    "))
+	if len(e.Chain) > 1 {
+		fmt.Fprintf(&sb, "\n    dependency chain: %s", strings.Join(e.Chain, " -> "))
+	}
+	return sb.String()
+}
+
+// SkippedComponent is a component not created because one of its dependencies failed
+type SkippedComponent struct {
+	Component string `json:"component"`
+	// Field is the field or the constructor parameter injected with Dependency, empty if unknown
+	Field string `json:"field,omitempty"`
+	// Dependency is the failed or skipped dependency
+	Dependency string `json:"dependency"`
+}
+
+func (s SkippedComponent) String() string {
+	if s.Field == "" {
+		return fmt.Sprintf("component '%s': dependency '%s' failed", s.Component, s.Dependency)
+	}
+	return fmt.Sprintf("component '%s' %s: dependency '%s' failed", s.Component, s.Field, s.Dependency)
+}
+
+// RefreshError aggregates the failures of a refresh continuing past failed components,
+// it is rendered as text by Error and as JSON by encoding/json.
+type RefreshError struct {
+	Failures []ComponentError   `json:"failures"`
+	Skipped  []SkippedComponent `json:"skipped,omitempty"`
+}
+
+func (e *RefreshError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d components failed, %d skipped:", len(e.Failures), len(e.Skipped))
+	for _, failure := range e.Failures {
+		sb.WriteString("\n  - ")
+		sb.WriteString(failure.String())
+	}
+	for _, skipped := range e.Skipped {
+		sb.WriteString("\n  - skipped ")
+		sb.WriteString(skipped.String())
+	}
+	return sb.String()
+}
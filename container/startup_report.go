@@ -0,0 +1,68 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ComponentTiming is the wall time spent creating a component,
+// the time spent creating its dependencies is accounted to the dependencies.
+type ComponentTiming struct {
+	Name string
+	// Instantiation is the time spent in the constructor
+	Instantiation time.Duration
+	// Population is the time spent injecting properties, excluding the post processors
+	Population time.Duration
+	// PostProcessors is the time spent in the ComponentPostProcessor calls for the component
+	PostProcessors time.Duration
+	// Init is the time spent in AfterPropertiesSet and Init
+	Init time.Duration
+	// Total is the time spent creating the component, it includes the phases above
+	Total time.Duration
+	// Creations is the number of instances created, it is greater than 1 for prototype components
+	Creations int
+}
+
+// PostProcessorTiming is the wall time spent in the calls of a ComponentPostProcessor
+type PostProcessorTiming struct {
+	Name  string
+	Calls int
+	Total time.Duration
+}
+
+// StartupReport is the timing of the component creations, sorted by Total from the slowest
+type StartupReport struct {
+	Components     []ComponentTiming
+	PostProcessors []PostProcessorTiming
+}
+
+// StartupReporter is implemented by factories measuring the component creations
+type StartupReporter interface {
+	GetStartupReport() *StartupReport
+}
+
+// Table formats the n slowest components and post processors, n <= 0 formats all of them
+func (r *StartupReport) Table(n int) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tTOTAL\tINSTANTIATION\tPOPULATION\tPOST PROCESSORS\tINIT\t")
+	for _, c := range top(r.Components, n) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t\n", c.Name, c.Total, c.Instantiation, c.Population, c.PostProcessors, c.Init)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "POST PROCESSOR\tTOTAL\tCALLS\t")
+	for _, p := range top(r.PostProcessors, n) {
+		fmt.Fprintf(w, "%s\t%s\t%d\t\n", p.Name, p.Total, p.Calls)
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
+func top[T any](s []T, n int) []T {
+	if n <= 0 || n >= len(s) {
+		return s
+	}
+	return s[:n]
+}
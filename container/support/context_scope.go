@@ -0,0 +1,165 @@
+package support
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/syslog"
+	"github.com/pkg/errors"
+)
+
+/*
+ContextScope
+A container.Scope whose instances live in a context.Context, used for 'request', 'session' or other short-lived scopes.
+The scope is started on a context by Begin, instances created in that context are destroyed when the context is done.
+*/
+type ContextScope struct {
+	name string
+}
+
+func NewContextScope(name string) *ContextScope {
+	return &ContextScope{name: name}
+}
+
+type contextScopeKey string
+
+type contextScopeEntry struct {
+	once sync.Once
+	meta *component_definition.Meta
+	err  error
+}
+
+type contextScopeStore struct {
+	mu        sync.Mutex
+	entries   map[string]*contextScopeEntry
+	callbacks map[string][]func()
+	names     []string
+	destroyed bool
+}
+
+func (s *ContextScope) Name() string {
+	return s.name
+}
+
+// Begin returns a child context of ctx which the scope is active in,
+// instances created in the returned context are destroyed when it is done.
+func (s *ContextScope) Begin(ctx context.Context) context.Context {
+	store := &contextScopeStore{
+		entries:   make(map[string]*contextScopeEntry),
+		callbacks: make(map[string][]func()),
+	}
+	ctx = context.WithValue(ctx, contextScopeKey(s.name), store)
+	context.AfterFunc(ctx, store.destroy)
+	return ctx
+}
+
+// IsActive reports whether the scope was started on ctx and has not been destroyed yet
+func (s *ContextScope) IsActive(ctx context.Context) bool {
+	store, ok := s.store(ctx)
+	if !ok {
+		return false
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return !store.destroyed
+}
+
+func (s *ContextScope) store(ctx context.Context) (*contextScopeStore, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	store, ok := ctx.Value(contextScopeKey(s.name)).(*contextScopeStore)
+	return store, ok
+}
+
+func (s *ContextScope) Get(ctx context.Context, name string, factory container.SingletonFactory) (*component_definition.Meta, error) {
+	store, ok := s.store(ctx)
+	if !ok {
+		return nil, errors.Errorf("scope '%s' is not active in current context, start it by ContextScope.Begin", s.name)
+	}
+	store.mu.Lock()
+	if store.destroyed {
+		store.mu.Unlock()
+		return nil, errors.Errorf("scope '%s' of current context has been destroyed", s.name)
+	}
+	entry, loaded := store.entries[name]
+	if !loaded {
+		entry = &contextScopeEntry{}
+		store.entries[name] = entry
+	}
+	store.mu.Unlock()
+
+	entry.once.Do(func() {
+		s.logger().Tracef("create instance of '%s' in context scope '%s'", name, s.name)
+		entry.meta, entry.err = factory.GetComponent()
+		if entry.err == nil {
+			store.mu.Lock()
+			store.names = append(store.names, name)
+			store.mu.Unlock()
+		}
+	})
+	return entry.meta, entry.err
+}
+
+func (s *ContextScope) Remove(ctx context.Context, name string) (*component_definition.Meta, bool) {
+	store, ok := s.store(ctx)
+	if !ok {
+		return nil, false
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	entry, loaded := store.entries[name]
+	if !loaded {
+		return nil, false
+	}
+	delete(store.entries, name)
+	delete(store.callbacks, name)
+	for i, n := range store.names {
+		if n == name {
+			store.names = append(store.names[:i], store.names[i+1:]...)
+			break
+		}
+	}
+	return entry.meta, entry.meta != nil
+}
+
+func (s *ContextScope) RegisterDestructionCallback(ctx context.Context, name string, callback func()) {
+	store, ok := s.store(ctx)
+	if !ok {
+		return
+	}
+	store.mu.Lock()
+	if store.destroyed {
+		// the context ended while the instance was being created
+		store.mu.Unlock()
+		callback()
+		return
+	}
+	store.callbacks[name] = append(store.callbacks[name], callback)
+	store.mu.Unlock()
+}
+
+// destroy runs the destruction callbacks in reverse creation order, so dependents are destroyed before their dependencies
+func (c *contextScopeStore) destroy() {
+	c.mu.Lock()
+	if c.destroyed {
+		c.mu.Unlock()
+		return
+	}
+	c.destroyed = true
+	names, callbacks := c.names, c.callbacks
+	c.entries, c.callbacks, c.names = nil, nil, nil
+	c.mu.Unlock()
+
+	for i := len(names) - 1; i >= 0; i-- {
+		for _, callback := range callbacks[names[i]] {
+			callback()
+		}
+	}
+}
+
+func (s *ContextScope) logger() syslog.Logger {
+	return syslog.Pref("ContextScope")
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/go-kid/ioc/syslog"
 	"github.com/go-kid/ioc/util/list"
 	"github.com/go-kid/ioc/util/sync2"
+	"github.com/samber/lo"
+	"sync"
 )
 
 type defaultSingletonComponentRegistry struct {
@@ -13,6 +15,8 @@ type defaultSingletonComponentRegistry struct {
 	earlySingletonObjects        *sync2.Map[string, *component_definition.Meta]
 	singletonFactories           *sync2.Map[string, container.SingletonFactory]
 	singletonCurrentlyInCreation list.Set
+	registeredSingletons         []string
+	mu                           sync.RWMutex
 }
 
 func DefaultSingletonComponentRegistry() container.SingletonComponentRegistry {
@@ -34,11 +38,19 @@ func (r *defaultSingletonComponentRegistry) RemoveSingleton(name string) {
 	r.earlySingletonObjects.Delete(name)
 	r.singletonFactories.Delete(name)
 	r.singletonCurrentlyInCreation.Remove(name)
+	r.mu.Lock()
+	r.registeredSingletons = lo.Without(r.registeredSingletons, name)
+	r.mu.Unlock()
 	r.logger().Tracef("remove singleton '%s'", name)
 }
 
 func (r *defaultSingletonComponentRegistry) AddSingleton(name string, meta *component_definition.Meta) {
+	r.mu.Lock()
+	if _, loaded := r.singletonObjects.Load(name); !loaded {
+		r.registeredSingletons = append(r.registeredSingletons, name)
+	}
 	r.singletonObjects.Store(name, meta)
+	r.mu.Unlock()
 	r.earlySingletonObjects.Delete(name)
 	r.singletonFactories.Delete(name)
 	r.logger().Tracef("put singleton '%s' to singleton objects", name)
@@ -94,6 +106,15 @@ func (r *defaultSingletonComponentRegistry) IsSingletonCurrentlyInCreation(name
 	return r.singletonCurrentlyInCreation.Exists(name)
 }
 
+// GetSingletonNames returns the names of fully created singletons in creation order
+func (r *defaultSingletonComponentRegistry) GetSingletonNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, len(r.registeredSingletons))
+	copy(names, r.registeredSingletons)
+	return names
+}
+
 func (r *defaultSingletonComponentRegistry) logger() syslog.Logger {
 	return syslog.Pref("SingletonComponentRegistry")
 }
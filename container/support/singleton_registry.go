@@ -1,12 +1,15 @@
 package support
 
 import (
+	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/container"
 	"github.com/go-kid/ioc/syslog"
 	"github.com/go-kid/ioc/util/framework_helper"
 	"github.com/go-kid/ioc/util/sync2"
 	"github.com/pkg/errors"
 	"reflect"
+	"slices"
+	"sync"
 )
 
 /*
@@ -15,8 +18,23 @@ Dependency Register and Dependency Lookup
 */
 
 type registry struct {
-	componentsMap  *sync2.Map[string, any]
-	constructorMap *sync2.Map[string, any]
+	componentsMap   *sync2.Map[string, any]
+	constructorMap  *sync2.Map[string, *component_definition.Constructor]
+	registrationMap *sync2.Map[string, *component_definition.Registration]
+
+	mu             sync.Mutex
+	overridePolicy container.OverridePolicy
+	replacements   map[string]*registryEntry
+	overrides      []container.ComponentOverride
+}
+
+// registryEntry is a registered component resolved to its name,
+// with the constructor or registration it was registered by
+type registryEntry struct {
+	name         string
+	singleton    any
+	constructor  *component_definition.Constructor
+	registration *component_definition.Registration
 }
 
 func (r *registry) GetSingleton(name string) (any, error) {
@@ -47,37 +65,145 @@ func (r *registry) GetSingletonCount() int {
 
 func NewRegistry() container.SingletonRegistry {
 	return &registry{
-		componentsMap:  sync2.New[string, any](),
-		constructorMap: sync2.New[string, any](),
+		componentsMap:   sync2.New[string, any](),
+		constructorMap:  sync2.New[string, *component_definition.Constructor](),
+		registrationMap: sync2.New[string, *component_definition.Registration](),
+		overridePolicy:  container.OverridePanic,
+		replacements:    make(map[string]*registryEntry),
 	}
 }
 
 func (r *registry) RegisterSingleton(singleton any) {
-	t := reflect.TypeOf(singleton)
-	if t.Kind() == reflect.Func {
-		singleton = r.registerConstructor(singleton, t)
+	entry := r.newEntry(singleton)
+	name := entry.name
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	exist, loaded := r.componentsMap.Load(name)
+	if !loaded {
+		r.store(entry)
+		return
+	}
+	if exist == entry.singleton {
+		return
+	}
+	if replacement, ok := r.replacements[name]; ok {
+		r.addOverride(container.ComponentOverride{Name: name, Previous: entry.singleton, Current: replacement.singleton, Replaced: true})
+		return
+	}
+	override := container.ComponentOverride{Name: name, Previous: exist, Current: entry.singleton, Policy: r.overridePolicy}
+	switch r.overridePolicy {
+	case container.OverrideError:
+	case container.OverrideLastWins:
+		override.Replaced = true
+		r.store(entry)
+	case container.OverrideFirstWins:
+	default:
+		r.logger().Panicf("register duplicated component %s", name)
 	}
-	name := framework_helper.GetComponentName(singleton)
+	r.addOverride(override)
+}
+
+func (r *registry) SetOverridePolicy(policy container.OverridePolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.overridePolicy = policy
+}
+
+func (r *registry) ReplaceSingleton(old, replacement any) {
+	name, isName := old.(string)
+	if !isName {
+		name = r.newEntry(old).name
+	}
+	entry := r.newEntry(replacement)
+	entry.name = name
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.replacements[name] = entry
 	if exist, loaded := r.componentsMap.Load(name); loaded {
-		if exist != singleton {
-			r.logger().Panicf("register duplicated component %s", name)
+		r.addOverride(container.ComponentOverride{Name: name, Previous: exist, Current: entry.singleton, Replaced: true})
+	}
+	r.store(entry)
+}
+
+func (r *registry) GetOverrides() []container.ComponentOverride {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.overrides)
+}
+
+func (r *registry) addOverride(override container.ComponentOverride) {
+	r.overrides = append(r.overrides, override)
+	switch {
+	case override.IsExplicit():
+		r.logger().Infof("component '%s' %T is replaced by %T", override.Name, override.Previous, override.Current)
+	case override.Policy == container.OverrideError:
+		r.logger().Errorf("register duplicated component '%s' %T, the registered %T is kept", override.Name, override.Current, override.Previous)
+	case override.Replaced:
+		r.logger().Warnf("component '%s' %T is overridden by %T (policy %s)", override.Name, override.Previous, override.Current, override.Policy)
+	default:
+		r.logger().Warnf("component '%s' %T is kept, discard %T (policy %s)", override.Name, override.Previous, override.Current, override.Policy)
+	}
+}
+
+// newEntry resolves the name of singleton and the zero instance registered for a constructor
+func (r *registry) newEntry(singleton any) *registryEntry {
+	entry := &registryEntry{singleton: singleton}
+	switch s := singleton.(type) {
+	case *component_definition.Registration:
+		if err := s.Validate(); err != nil {
+			r.logger().Panicf("register component failed: %v", err)
 		}
-		return
+		entry.name, entry.singleton, entry.registration = s.Name, s.Component, s
+		return entry
+	case *component_definition.Constructor:
+		entry.constructor = s
+	default:
+		if reflect.TypeOf(singleton).Kind() == reflect.Func {
+			entry.constructor = component_definition.NewConstructor(singleton)
+		}
+	}
+	if entry.constructor != nil {
+		entry.singleton = r.newConstructorInstance(entry.constructor)
+		entry.name = entry.constructor.Name
+	}
+	if entry.name == "" {
+		entry.name = framework_helper.GetComponentName(entry.singleton)
+	}
+	return entry
+}
+
+func (r *registry) store(entry *registryEntry) {
+	name := entry.name
+	if entry.constructor != nil {
+		r.constructorMap.Store(name, entry.constructor)
+		r.logger().Tracef("singleton registry register constructor %s for component %s", entry.constructor.Type, name)
+	} else {
+		r.constructorMap.Delete(name)
+	}
+	if entry.registration != nil {
+		r.registrationMap.Store(name, entry.registration)
+	} else {
+		r.registrationMap.Delete(name)
 	}
-	r.componentsMap.Store(name, singleton)
+	r.componentsMap.Store(name, entry.singleton)
 	r.logger().Tracef("singleton registry register component %s", name)
 }
 
-func (r *registry) registerConstructor(constructor any, t reflect.Type) any {
+// newConstructorInstance validates the constructor and returns a zero instance of the component type it produces,
+// the zero instance is registered as placeholder of the component until the constructor is invoked.
+func (r *registry) newConstructorInstance(constructor *component_definition.Constructor) any {
+	t := constructor.Type
+	if t == nil || t.Kind() != reflect.Func {
+		r.logger().Panicf("register constructor failed: %T is not a function", constructor.Fn)
+	}
 	returnType, err := validateConstructor(t)
+	if err == nil {
+		err = constructor.ValidateParams()
+	}
 	if err != nil {
 		r.logger().Panicf("register constructor %s failed: %v", t.String(), err)
 	}
-	zeroInstance := reflect.New(returnType.Elem()).Interface()
-	name := framework_helper.GetComponentName(zeroInstance)
-	r.constructorMap.Store(name, constructor)
-	r.logger().Tracef("singleton registry register constructor %s for component %s", t.String(), name)
-	return zeroInstance
+	return reflect.New(returnType.Elem()).Interface()
 }
 
 var errorType = reflect.TypeOf((*error)(nil)).Elem()
@@ -103,9 +229,22 @@ func validateConstructor(t reflect.Type) (reflect.Type, error) {
 }
 
 func (r *registry) GetConstructor(name string) (any, bool) {
+	constructor, ok := r.constructorMap.Load(name)
+	if !ok {
+		return nil, false
+	}
+	return constructor.Fn, true
+}
+
+// GetConstructorDefinition returns the constructor registered for name with its parameter annotations
+func (r *registry) GetConstructorDefinition(name string) (*component_definition.Constructor, bool) {
 	return r.constructorMap.Load(name)
 }
 
+func (r *registry) GetRegistration(name string) (*component_definition.Registration, bool) {
+	return r.registrationMap.Load(name)
+}
+
 func (r *registry) logger() syslog.Logger {
 	return syslog.Pref("SingletonRegistry")
 }
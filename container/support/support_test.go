@@ -51,6 +51,25 @@ func TestRegistry_RegisterSingleton_Constructor(t *testing.T) {
 	constructor, ok := r.GetConstructor(name)
 	assert.True(t, ok)
 	assert.NotNil(t, constructor)
+
+	definition, ok := r.GetConstructorDefinition(name)
+	assert.True(t, ok)
+	assert.Equal(t, reflect.ValueOf(constructor).Pointer(), reflect.ValueOf(definition.Fn).Pointer())
+}
+
+func TestRegistry_RegisterSingleton_NamedConstructors(t *testing.T) {
+	r := NewRegistry().(*registry)
+	r.RegisterSingleton(component_definition.NewConstructor(newTestComponent).Named("first"))
+	r.RegisterSingleton(component_definition.NewConstructor(newTestComponent).Named("second"))
+
+	for _, name := range []string{"first", "second"} {
+		got, err := r.GetSingleton(name)
+		assert.NoError(t, err)
+		assert.IsType(t, &testComponent{}, got)
+		_, ok := r.GetConstructor(name)
+		assert.True(t, ok)
+	}
+	assert.False(t, r.ContainsSingleton(framework_helper.GetComponentName(&testComponent{})))
 }
 
 func TestRegistry_RegisterSingleton_DuplicateSameInstance(t *testing.T) {
@@ -237,3 +256,58 @@ func TestDefaultSingletonComponentRegistry_GetSingletonOrCreateByFactory(t *test
 	assert.Same(t, first, second)
 	assert.Equal(t, 1, createCount)
 }
+
+func TestRegistry_OverridePolicy(t *testing.T) {
+	type dupComp struct{ x int }
+	first, second := &dupComp{x: 1}, &dupComp{x: 2}
+	name := framework_helper.GetComponentName(first)
+	tests := []struct {
+		policy   container.OverridePolicy
+		expected any
+		replaced bool
+	}{
+		{container.OverrideError, first, false},
+		{container.OverrideLastWins, second, true},
+		{container.OverrideFirstWins, first, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			r := NewRegistry().(*registry)
+			r.SetOverridePolicy(tt.policy)
+			r.RegisterSingleton(first)
+			r.RegisterSingleton(second)
+			got, err := r.GetSingleton(name)
+			assert.NoError(t, err)
+			assert.Same(t, tt.expected, got)
+			overrides := r.GetOverrides()
+			assert.Len(t, overrides, 1)
+			assert.Equal(t, tt.policy, overrides[0].Policy)
+			assert.Equal(t, tt.replaced, overrides[0].Replaced)
+		})
+	}
+}
+
+func TestRegistry_ReplaceSingleton(t *testing.T) {
+	type dupComp struct{ x int }
+	origin, stub := &dupComp{x: 1}, &dupComp{x: 2}
+	name := framework_helper.GetComponentName(origin)
+
+	t.Run("BeforeRegistration", func(t *testing.T) {
+		r := NewRegistry().(*registry)
+		r.ReplaceSingleton(origin, stub)
+		r.RegisterSingleton(origin)
+		got, _ := r.GetSingleton(name)
+		assert.Same(t, stub, got)
+		assert.Len(t, r.GetOverrides(), 1)
+		assert.True(t, r.GetOverrides()[0].IsExplicit())
+	})
+	t.Run("AfterRegistration", func(t *testing.T) {
+		r := NewRegistry().(*registry)
+		r.RegisterSingleton(newTestComponent)
+		r.ReplaceSingleton(framework_helper.GetComponentName(&testComponent{}), origin)
+		got, _ := r.GetSingleton(framework_helper.GetComponentName(&testComponent{}))
+		assert.Same(t, origin, got)
+		_, hasConstructor := r.GetConstructor(framework_helper.GetComponentName(&testComponent{}))
+		assert.False(t, hasConstructor)
+	})
+}
@@ -0,0 +1,60 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WiringProblemKind classifies the problems found by WiringValidator
+type WiringProblemKind string
+
+const (
+	// ProblemUnresolved is a required field or constructor parameter without any candidate component
+	ProblemUnresolved WiringProblemKind = "unresolved"
+	// ProblemAmbiguous is a single-valued field or constructor parameter with several candidates and no unique primary one,
+	// it is only reported in strict wiring mode
+	ProblemAmbiguous WiringProblemKind = "ambiguous"
+	// ProblemMissingConfig is a required configuration value whose key is neither configured nor defaulted
+	ProblemMissingConfig WiringProblemKind = "missing_config"
+	// ProblemCycle is a circular reference through constructor parameters, which can not be early exposed
+	ProblemCycle WiringProblemKind = "cycle"
+	// ProblemInvalid is any other failure of the property post processors
+	ProblemInvalid WiringProblemKind = "invalid"
+)
+
+// WiringProblem is a wiring error found without creating the components
+type WiringProblem struct {
+	Kind      WiringProblemKind
+	Component string
+	// Field is the field name or the constructor parameter (e.g. "param[0]"), empty for cycles
+	Field  string
+	Reason string
+}
+
+func (p WiringProblem) String() string {
+	if p.Field == "" {
+		return fmt.Sprintf("[%s] component '%s': %s", p.Kind, p.Component, p.Reason)
+	}
+	return fmt.Sprintf("[%s] component '%s' %s: %s", p.Kind, p.Component, p.Field, p.Reason)
+}
+
+// ValidationError reports all the problems found by WiringValidator
+type ValidationError struct {
+	Problems []WiringProblem
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d wiring problems found:", len(e.Problems))
+	for _, problem := range e.Problems {
+		sb.WriteString("\n  - ")
+		sb.WriteString(problem.String())
+	}
+	return sb.String()
+}
+
+// WiringValidator is implemented by factories checking the wiring of the prepared components
+// without instantiating them, calling init methods or runners.
+type WiringValidator interface {
+	ValidateWiring() []WiringProblem
+}
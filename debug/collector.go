@@ -10,9 +10,12 @@ type Collector struct {
 }
 
 type componentInfo struct {
-	Name  string         `json:"name"`
-	Type  string         `json:"type"`
-	State ComponentState `json:"state"`
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	State     ComponentState `json:"state"`
+	Scope     string         `json:"scope,omitempty"`
+	Lazy      bool           `json:"lazy,omitempty"`
+	Overrides []OverrideInfo `json:"overrides,omitempty"`
 }
 
 func NewCollector() *Collector {
@@ -21,11 +24,11 @@ func NewCollector() *Collector {
 	}
 }
 
-func (c *Collector) RegisterComponent(name, typeName string) {
+func (c *Collector) RegisterComponent(name, typeName, scope string, lazy bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if _, ok := c.components[name]; !ok {
-		c.components[name] = &componentInfo{Name: name, Type: typeName, State: StateRegistered}
+		c.components[name] = &componentInfo{Name: name, Type: typeName, State: StateRegistered, Scope: scope, Lazy: lazy}
 	}
 }
 
@@ -37,6 +40,14 @@ func (c *Collector) SetState(name string, state ComponentState) {
 	}
 }
 
+func (c *Collector) AddOverride(name string, override OverrideInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if info, ok := c.components[name]; ok {
+		info.Overrides = append(info.Overrides, override)
+	}
+}
+
 func (c *Collector) AddEdge(edge DependencyEdge) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -58,6 +69,8 @@ func (c *Collector) GetGraph() GraphData {
 			Name:  info.Name,
 			Type:  info.Type,
 			State: info.State,
+			Scope: info.Scope,
+			Lazy:  info.Lazy,
 		})
 	}
 	edges := make([]DependencyEdge, len(c.edges))
@@ -70,7 +83,9 @@ func (c *Collector) GetComponents() []componentInfo {
 	defer c.mu.RUnlock()
 	result := make([]componentInfo, 0, len(c.components))
 	for _, info := range c.components {
-		result = append(result, *info)
+		ci := *info
+		ci.Overrides = append([]OverrideInfo(nil), info.Overrides...)
+		result = append(result, ci)
 	}
 	return result
 }
@@ -43,8 +43,18 @@ type GraphData struct {
 	Edges []DependencyEdge `json:"edges"`
 }
 
+// OverrideInfo describes a registration conflicting on the name of a component
+type OverrideInfo struct {
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
+	Policy   string `json:"policy,omitempty"` // empty for an explicit replacement
+	Replaced bool   `json:"replaced"`
+}
+
 type GraphNode struct {
 	Name  string         `json:"name"`
 	Type  string         `json:"type"`
 	State ComponentState `json:"state"`
+	Scope string         `json:"scope,omitempty"`
+	Lazy  bool           `json:"lazy,omitempty"`
 }
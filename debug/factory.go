@@ -9,6 +9,7 @@ import (
 	"runtime"
 
 	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/configure"
 	"github.com/go-kid/ioc/container"
 	"github.com/go-kid/ioc/container/factory"
@@ -23,6 +24,30 @@ type contextSetter interface {
 	SetContext(ctx context.Context)
 }
 
+type strictWiringSetter interface {
+	SetStrictWiring(strict bool)
+}
+
+type parallelismSetter interface {
+	SetParallelism(workers int)
+}
+
+type aggregateErrorsSetter interface {
+	SetAggregateErrors(aggregate bool)
+}
+
+type circularReferencesSetter interface {
+	SetAllowCircularReferences(allow bool)
+}
+
+type startupMeasureSetter interface {
+	SetMeasureStartup(measure bool)
+}
+
+type componentObserverSetter interface {
+	SetComponentObserver(observer container.ComponentObserver)
+}
+
 type DebugOption func(*DebugFactory)
 
 func WithDryRun() DebugOption {
@@ -168,6 +193,26 @@ func (df *DebugFactory) GetComponentByName(name string) (any, error) {
 	return df.inner.GetComponentByName(name)
 }
 
+func (df *DebugFactory) GetComponentByNameWithContext(ctx context.Context, name string) (any, error) {
+	if sf, ok := df.inner.(container.ScopedFactory); ok {
+		return sf.GetComponentByNameWithContext(ctx, name)
+	}
+	return nil, fmt.Errorf("factory %T does not support custom scopes", df.inner)
+}
+
+func (df *DebugFactory) RegisterScope(name string, scope container.Scope) {
+	if sf, ok := df.inner.(container.ScopedFactory); ok {
+		sf.RegisterScope(name, scope)
+	}
+}
+
+func (df *DebugFactory) GetRegisteredScope(name string) (container.Scope, bool) {
+	if sf, ok := df.inner.(container.ScopedFactory); ok {
+		return sf.GetRegisteredScope(name)
+	}
+	return nil, false
+}
+
 func (df *DebugFactory) GetConfigure() configure.Configure {
 	return df.inner.GetConfigure()
 }
@@ -176,6 +221,77 @@ func (df *DebugFactory) GetDefinitionRegistry() container.DefinitionRegistry {
 	return df.inner.GetDefinitionRegistry()
 }
 
+func (df *DebugFactory) GetSingletons() []*component_definition.Meta {
+	if l, ok := df.inner.(container.SingletonLister); ok {
+		return l.GetSingletons()
+	}
+	return nil
+}
+
+func (df *DebugFactory) DestroySingletons() error {
+	if d, ok := df.inner.(container.SingletonDestroyer); ok {
+		return d.DestroySingletons()
+	}
+	return fmt.Errorf("factory %T does not support destroying the singletons", df.inner)
+}
+
+func (df *DebugFactory) SetStrictWiring(strict bool) {
+	if sw, ok := df.inner.(strictWiringSetter); ok {
+		sw.SetStrictWiring(strict)
+	}
+}
+
+func (df *DebugFactory) GetStartupReport() *container.StartupReport {
+	if sr, ok := df.inner.(container.StartupReporter); ok {
+		return sr.GetStartupReport()
+	}
+	return &container.StartupReport{}
+}
+
+func (df *DebugFactory) ValidateWiring() []container.WiringProblem {
+	if v, ok := df.inner.(container.WiringValidator); ok {
+		return v.ValidateWiring()
+	}
+	return nil
+}
+
+func (df *DebugFactory) SetParallelism(workers int) {
+	if ps, ok := df.inner.(parallelismSetter); ok {
+		ps.SetParallelism(workers)
+	}
+}
+
+func (df *DebugFactory) SetAggregateErrors(aggregate bool) {
+	if as, ok := df.inner.(aggregateErrorsSetter); ok {
+		as.SetAggregateErrors(aggregate)
+	}
+}
+
+func (df *DebugFactory) SetMeasureStartup(measure bool) {
+	if ms, ok := df.inner.(startupMeasureSetter); ok {
+		ms.SetMeasureStartup(measure)
+	}
+}
+
+func (df *DebugFactory) SetAllowCircularReferences(allow bool) {
+	if cs, ok := df.inner.(circularReferencesSetter); ok {
+		cs.SetAllowCircularReferences(allow)
+	}
+}
+
+func (df *DebugFactory) SetComponentObserver(observer container.ComponentObserver) {
+	if obs, ok := df.inner.(componentObserverSetter); ok {
+		obs.SetComponentObserver(observer)
+	}
+}
+
+func (df *DebugFactory) GetCircularReferences() []container.CircularReference {
+	if r, ok := df.inner.(container.CircularReferenceReporter); ok {
+		return r.GetCircularReferences()
+	}
+	return nil
+}
+
 func (df *DebugFactory) SetContext(ctx context.Context) {
 	if cs, ok := df.inner.(contextSetter); ok {
 		cs.SetContext(ctx)
@@ -0,0 +1,158 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-kid/ioc/app"
+)
+
+const graphFlagPrefix = "--ioc:graph="
+
+type GraphFormat string
+
+const (
+	GraphFormatDOT     GraphFormat = "dot"
+	GraphFormatMermaid GraphFormat = "mermaid"
+	GraphFormatJSON    GraphFormat = "json"
+)
+
+// GraphFormatOf returns the format of a graph file by its extension,
+// .mmd and .mermaid are Mermaid, .json is JSON, others are Graphviz DOT.
+func GraphFormatOf(path string) GraphFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mmd", ".mermaid":
+		return GraphFormatMermaid
+	case ".json":
+		return GraphFormatJSON
+	default:
+		return GraphFormatDOT
+	}
+}
+
+// GraphFlag returns the file given by the --ioc:graph=<file> CLI flag
+func GraphFlag() (string, bool) {
+	for _, arg := range os.Args[1:] {
+		if path, ok := strings.CutPrefix(arg, graphFlagPrefix); ok && path != "" {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// SetupGraph creates a dry run factory collecting the component graph without the debug server,
+// it returns the app.SettingOption slice to apply and the function returning the graph once the app has run.
+func SetupGraph() ([]app.SettingOption, func() GraphData) {
+	df := newDebugFactory(WithDryRun())
+	df.controller.SetMode(ModeRun)
+	return []app.SettingOption{app.SetFactory(df), app.SkipRunners()}, df.collector.GetGraph
+}
+
+// WriteGraph writes graph in format, nodes and edges are sorted so the output is stable across runs
+func WriteGraph(w io.Writer, graph GraphData, format GraphFormat) error {
+	graph = sortedGraph(graph)
+	switch format {
+	case GraphFormatDOT:
+		return writeDOT(w, graph)
+	case GraphFormatMermaid:
+		return writeMermaid(w, graph)
+	case GraphFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(graph)
+	default:
+		return fmt.Errorf("unsupported graph format '%s'", format)
+	}
+}
+
+// sortedGraph sorts the nodes by name and the edges by component and field, duplicated edges are removed
+func sortedGraph(graph GraphData) GraphData {
+	nodes := slices.Clone(graph.Nodes)
+	slices.SortFunc(nodes, func(a, b GraphNode) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	edges := slices.Clone(graph.Edges)
+	slices.SortFunc(edges, func(a, b DependencyEdge) int {
+		for _, c := range [][2]string{{a.From, b.From}, {a.FieldName, b.FieldName}, {a.To, b.To}, {a.DepType, b.DepType}} {
+			if r := strings.Compare(c[0], c[1]); r != 0 {
+				return r
+			}
+		}
+		return 0
+	})
+	return GraphData{Nodes: nodes, Edges: slices.Compact(edges)}
+}
+
+func nodeLabel(node GraphNode) string {
+	label := node.Name
+	var flags []string
+	if node.Scope != "" {
+		flags = append(flags, node.Scope)
+	}
+	if node.Lazy {
+		flags = append(flags, "lazy")
+	}
+	if len(flags) != 0 {
+		label += "\n" + strings.Join(flags, ", ")
+	}
+	return label
+}
+
+func writeDOT(w io.Writer, graph GraphData) error {
+	var sb strings.Builder
+	sb.WriteString("digraph components {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&sb, "  %q [label=%q", node.Name, nodeLabel(node))
+		if node.Lazy {
+			sb.WriteString(", style=dashed")
+		}
+		sb.WriteString("];\n")
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q", edge.From, edge.To, edge.FieldName)
+		if edge.DepType != "pointer" {
+			fmt.Fprintf(&sb, ", style=dashed, tooltip=%q", edge.DepType)
+		}
+		sb.WriteString("];\n")
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeMermaid(w io.Writer, graph GraphData) error {
+	ids := make(map[string]string, len(graph.Nodes))
+	id := func(name string) string {
+		if i, ok := ids[name]; ok {
+			return i
+		}
+		i := fmt.Sprintf("n%d", len(ids))
+		ids[name] = i
+		return i
+	}
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", id(node.Name), mermaidText(strings.ReplaceAll(nodeLabel(node), "\n", "<br/>")))
+	}
+	for _, edge := range graph.Edges {
+		arrow := "-->"
+		if edge.DepType != "pointer" {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&sb, "  %s %s|\"%s\"| %s\n", id(edge.From), arrow, mermaidText(edge.FieldName), id(edge.To))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func mermaidText(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -69,7 +69,20 @@ func (h *debugHook) updateCollector(event DebugEvent) {
 	switch event.Action {
 	case "component_registered":
 		typeName, _ := event.Details["type"].(string)
-		h.collector.RegisterComponent(event.ComponentName, typeName)
+		scope, _ := event.Details["scope"].(string)
+		lazy, _ := event.Details["lazy"].(bool)
+		h.collector.RegisterComponent(event.ComponentName, typeName, scope, lazy)
+	case "component_overridden":
+		previous, _ := event.Details["previous"].(string)
+		current, _ := event.Details["current"].(string)
+		policy, _ := event.Details["policy"].(string)
+		replaced, _ := event.Details["replaced"].(bool)
+		h.collector.AddOverride(event.ComponentName, OverrideInfo{
+			Previous: previous,
+			Current:  current,
+			Policy:   policy,
+			Replaced: replaced,
+		})
 	case "definition_scanned":
 		h.collector.SetState(event.ComponentName, StateScanned)
 	case "component_creating":
@@ -61,6 +61,12 @@
           state: 'registered'
         };
         break;
+      case 'component_overridden':
+        if (state.components[ev.componentName] && ev.details) {
+          var c = state.components[ev.componentName];
+          c.overrides = (c.overrides || []).concat([ev.details]);
+        }
+        break;
       case 'definition_scanned':
         if (ev.details && Array.isArray(ev.details.components)) {
           ev.details.components.forEach(function (n) {
@@ -172,6 +178,11 @@
     }
     var c = state.components[name];
     detailInfo.innerHTML = '<strong>' + escHtml(name) + '</strong><br>Type: ' + escHtml(c.type) + '<br>State: ' + c.state;
+    (c.overrides || []).forEach(function (o) {
+      var how = o.policy ? 'policy ' + o.policy : 'replaced';
+      detailInfo.innerHTML += '<br>Override: ' + escHtml(o.previous) + (o.replaced ? ' &rarr; ' : ' kept, discarded ') +
+        escHtml(o.current) + ' (' + escHtml(how) + ')';
+    });
 
     var deps = state.edges.filter(function (e) { return e.from === name; });
     detailDeps.innerHTML = deps.length === 0 ? '<li style="color:var(--text2)">None</li>' : '';
@@ -1,5 +1,11 @@
 package definition
 
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
 type ApplicationEvent interface {
 	Source() interface{}
 }
@@ -8,25 +14,154 @@ type ApplicationEventListener interface {
 	OnEvent(event ApplicationEvent) error
 }
 
+// EventListener is implemented by listeners of the events assignable to E only,
+// E is an event type or an interface implemented by a family of events.
+// Components with such an OnEvent method are detected by its signature.
+type EventListener[E any] interface {
+	OnEvent(event E) error
+}
+
+// ConditionalEventListener is implemented by listeners filtering the events they accept before OnEvent is called,
+// E is the event type of OnEvent or an interface implemented by it. Condition methods are detected by their signature.
+type ConditionalEventListener[E any] interface {
+	Condition(event E) bool
+}
+
 type ApplicationEventPublisher interface {
 	PublishEvent(event ApplicationEvent) error
 }
 
+// ApplicationEventPublisherWithContext publishes events within the unit of work bound to ctx,
+// the events are kept for the TransactionalEventListener until the unit of work ends.
+type ApplicationEventPublisherWithContext interface {
+	PublishEventWithContext(ctx context.Context, event ApplicationEvent) error
+}
+
+// TransactionPhase is the end of a unit of work a TransactionalEventListener receives the events at
+type TransactionPhase int
+
+const (
+	// AfterCommit delivers the events once the unit of work is committed
+	AfterCommit TransactionPhase = iota + 1
+	// AfterRollback delivers the events once the unit of work is rolled back
+	AfterRollback
+	// AfterCompletion delivers the events once the unit of work is committed or rolled back
+	AfterCompletion
+)
+
+// TransactionalEventListener is implemented by listeners receiving the events published within a unit of work
+// when it ends instead of when they are published. The events published outside a unit of work are not delivered to them.
+type TransactionalEventListener interface {
+	TransactionPhase() TransactionPhase
+}
+
+// ApplicationEventMulticaster delivers a published event to the listeners accepting it,
+// a component implementing it replaces the synchronous delivery stopping at the first failed listener.
+type ApplicationEventMulticaster interface {
+	MulticastEvent(event ApplicationEvent, listeners []ApplicationEventListener) error
+}
+
+// EventErrorHandler is implemented by listeners handling their own errors before the multicaster does,
+// the returned error is handled by the error policy of the multicaster, nil ignores err.
+type EventErrorHandler interface {
+	HandleEventError(event ApplicationEvent, err error) error
+}
+
+// ConfigurationLoadedEvent is published once the configuration is loaded, before the components are created
+type ConfigurationLoadedEvent struct {
+	App     interface{}
+	Elapsed time.Duration
+}
+
+func (e *ConfigurationLoadedEvent) Source() interface{} { return e.App }
+
+// ComponentCreatedEvent is published once a component is instantiated, before its properties are injected.
+// Elapsed is the time since its creation started, including the creation of its constructor dependencies.
 type ComponentCreatedEvent struct {
 	ComponentName string
 	Component     interface{}
+	Type          reflect.Type
+	Scope         string
+	Elapsed       time.Duration
 }
 
 func (e *ComponentCreatedEvent) Source() interface{} { return e.Component }
 
+// ComponentInitializedEvent is published once a component is injected and initialized.
+// Elapsed is the time since its creation started, including the creation of its dependencies.
+type ComponentInitializedEvent struct {
+	ComponentName string
+	Component     interface{}
+	Type          reflect.Type
+	Scope         string
+	Elapsed       time.Duration
+}
+
+func (e *ComponentInitializedEvent) Source() interface{} { return e.Component }
+
+// RefreshFinishedEvent is published once the singletons are created
+type RefreshFinishedEvent struct {
+	App        interface{}
+	Components int
+	Elapsed    time.Duration
+}
+
+func (e *RefreshFinishedEvent) Source() interface{} { return e.App }
+
+// RunnersStartedEvent is published once every ApplicationRunner returned successfully,
+// with no Runners when there are none or they are skipped
+type RunnersStartedEvent struct {
+	App     interface{}
+	Runners int
+	Elapsed time.Duration
+}
+
+func (e *RunnersStartedEvent) Source() interface{} { return e.App }
+
+// RunnerFailedEvent is published when an ApplicationRunner fails, the application does not start
+type RunnerFailedEvent struct {
+	App     interface{}
+	Runner  interface{}
+	Err     error
+	Elapsed time.Duration
+}
+
+func (e *RunnerFailedEvent) Source() interface{} { return e.Runner }
+
 type ApplicationStartedEvent struct {
 	App interface{}
 }
 
 func (e *ApplicationStartedEvent) Source() interface{} { return e.App }
 
+// ApplicationReadyEvent is published last when the application runs up, Elapsed is the whole startup time
+type ApplicationReadyEvent struct {
+	App     interface{}
+	Elapsed time.Duration
+}
+
+func (e *ApplicationReadyEvent) Source() interface{} { return e.App }
+
 type ApplicationClosingEvent struct {
 	App interface{}
 }
 
 func (e *ApplicationClosingEvent) Source() interface{} { return e.App }
+
+// CloserFinishedEvent is published once a CloserComponent is closed, Err is its error
+type CloserFinishedEvent struct {
+	App     interface{}
+	Closer  interface{}
+	Err     error
+	Elapsed time.Duration
+}
+
+func (e *CloserFinishedEvent) Source() interface{} { return e.Closer }
+
+// ApplicationClosedEvent is published last when the application is closed, Elapsed is the whole shutdown time
+type ApplicationClosedEvent struct {
+	App     interface{}
+	Elapsed time.Duration
+}
+
+func (e *ApplicationClosedEvent) Source() interface{} { return e.App }
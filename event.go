@@ -0,0 +1,14 @@
+package ioc
+
+import (
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/definition"
+)
+
+// Subscribe registers listener for the events published by a that are assignable to E,
+// E is an event type or an interface implemented by a family of events. The returned function removes the listener.
+func Subscribe[E any](a *app.App, listener func(event E) error) (unsubscribe func()) {
+	return a.Subscribe(typeOf[E](), func(event definition.ApplicationEvent) error {
+		return listener(event.(E))
+	})
+}
@@ -0,0 +1,374 @@
+package ioc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/definition"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderEvent interface {
+	definition.ApplicationEvent
+	OrderID() string
+}
+
+type orderPlaced struct{ id string }
+
+func (e *orderPlaced) Source() any     { return e.id }
+func (e *orderPlaced) OrderID() string { return e.id }
+
+type orderCancelled struct{ id string }
+
+func (e *orderCancelled) Source() any     { return e.id }
+func (e *orderCancelled) OrderID() string { return e.id }
+
+type placedListener struct{ received []string }
+
+func (l *placedListener) OnEvent(e *orderPlaced) error {
+	l.received = append(l.received, e.id)
+	return nil
+}
+
+type orderListener struct{ received []string }
+
+func (l *orderListener) OnEvent(e orderEvent) error {
+	l.received = append(l.received, e.OrderID())
+	return nil
+}
+
+type allEventsListener struct{ received int }
+
+func (l *allEventsListener) OnEvent(definition.ApplicationEvent) error {
+	l.received++
+	return nil
+}
+
+var (
+	_ definition.EventListener[*orderPlaced] = (*placedListener)(nil)
+	_ definition.EventListener[orderEvent]   = (*orderListener)(nil)
+)
+
+func TestTypedEventListeners(t *testing.T) {
+	placed, orders, all := &placedListener{}, &orderListener{}, &allEventsListener{}
+	a := RunTest(t, app.LogError, app.SetComponents(placed, orders, all))
+	started := all.received
+
+	var cancelled []string
+	unsubscribe := Subscribe(a, func(e *orderCancelled) error {
+		cancelled = append(cancelled, e.id)
+		return nil
+	})
+
+	assert.NoError(t, a.PublishEvent(&orderPlaced{id: "1"}))
+	assert.NoError(t, a.PublishEvent(&orderCancelled{id: "2"}))
+	unsubscribe()
+	assert.NoError(t, a.PublishEvent(&orderCancelled{id: "3"}))
+
+	assert.Equal(t, []string{"1"}, placed.received)
+	assert.Equal(t, []string{"1", "2", "3"}, orders.received)
+	assert.Equal(t, []string{"2"}, cancelled)
+	assert.Equal(t, started+3, all.received)
+}
+
+// orderImporter publishes an event while it is initialized, before the listeners are registered
+type orderImporter struct {
+	Publisher definition.ApplicationEventPublisher `wire:""`
+}
+
+func (i *orderImporter) Init() error {
+	return i.Publisher.PublishEvent(&orderPlaced{id: "imported"})
+}
+
+type placedRecorder struct {
+	received []string
+	err      error
+}
+
+func (l *placedRecorder) OnEvent(event definition.ApplicationEvent) error {
+	if e, ok := event.(*orderPlaced); ok {
+		l.received = append(l.received, e.id)
+		return l.err
+	}
+	return nil
+}
+
+func TestEventsPublishedDuringRefresh(t *testing.T) {
+	t.Run("Delivered", func(t *testing.T) {
+		recorder := &placedRecorder{}
+		RunTest(t, app.LogError, app.SetComponents(recorder, &orderImporter{}))
+		assert.Equal(t, []string{"imported"}, recorder.received)
+	})
+	t.Run("ListenerFailed", func(t *testing.T) {
+		recorder := &placedRecorder{err: errors.New("import rejected")}
+		err := app.NewApp().Run(app.LogError, app.SetComponents(recorder, &orderImporter{}))
+		assert.ErrorContains(t, err, "import rejected")
+	})
+}
+
+type failingOrderListener struct {
+	handled []error
+}
+
+func (l *failingOrderListener) OnEvent(e *orderPlaced) error {
+	return errors.New("audit failed")
+}
+
+func (l *failingOrderListener) HandleEventError(event definition.ApplicationEvent, err error) error {
+	l.handled = append(l.handled, err)
+	return err
+}
+
+func TestEventMulticaster(t *testing.T) {
+	failing := func(name string, calls *[]string) func(*orderPlaced) error {
+		return func(*orderPlaced) error {
+			*calls = append(*calls, name)
+			return errors.New(name + " failed")
+		}
+	}
+	t.Run("StopOnError", func(t *testing.T) {
+		a := RunTest(t, app.LogError)
+		var calls []string
+		Subscribe(a, failing("first", &calls))
+		Subscribe(a, failing("second", &calls))
+		assert.ErrorContains(t, a.PublishEvent(&orderPlaced{id: "1"}), "first failed")
+		assert.Equal(t, []string{"first"}, calls)
+	})
+	t.Run("ContinueOnError", func(t *testing.T) {
+		listener := &failingOrderListener{}
+		var handled []any
+		a := RunTest(t, app.LogError, app.SetComponents(listener, app.NewEventMulticaster(
+			app.WithErrorPolicy(app.ContinueOnError),
+			app.WithErrorHandler(func(event definition.ApplicationEvent, l any, err error) error {
+				handled = append(handled, l)
+				return err
+			}),
+		)))
+		var calls []string
+		Subscribe(a, failing("first", &calls))
+		Subscribe(a, failing("second", &calls))
+
+		err := a.PublishEvent(&orderPlaced{id: "1"})
+		assert.ErrorContains(t, err, "audit failed")
+		assert.ErrorContains(t, err, "first failed")
+		assert.ErrorContains(t, err, "second failed")
+		assert.Equal(t, []string{"first", "second"}, calls)
+		assert.Len(t, listener.handled, 1)
+		if assert.Len(t, handled, 3) {
+			assert.Same(t, listener, handled[0])
+		}
+	})
+	t.Run("Async", func(t *testing.T) {
+		var (
+			running, maxRunning atomic.Int32
+			failures            atomic.Int32
+			release             = make(chan struct{})
+		)
+		multicaster := app.NewEventMulticaster(app.WithAsync(2), app.WithErrorHandler(
+			func(definition.ApplicationEvent, any, error) error {
+				failures.Add(1)
+				return nil
+			}))
+		a := RunTest(t, app.LogError, app.SetComponents(multicaster))
+		for i := 0; i < 4; i++ {
+			Subscribe(a, func(*orderPlaced) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for m := maxRunning.Load(); n > m && !maxRunning.CompareAndSwap(m, n); m = maxRunning.Load() {
+				}
+				<-release
+				return errors.New("slow listener failed")
+			})
+		}
+		assert.NoError(t, a.PublishEvent(&orderPlaced{id: "1"}), "the publisher does not wait for the listeners")
+		assert.Eventually(t, func() bool { return running.Load() == 2 }, time.Second, time.Millisecond)
+		assert.Zero(t, failures.Load())
+		close(release)
+		assert.NoError(t, multicaster.(definition.CloserComponent).Close())
+		assert.Equal(t, int32(2), maxRunning.Load())
+		assert.Equal(t, int32(4), failures.Load())
+	})
+	t.Run("Overflow", func(t *testing.T) {
+		publish := func(t *testing.T, overflow app.OverflowPolicy) (delivered func() []string, done func()) {
+			var (
+				mu          sync.Mutex
+				ids         []string
+				started     = make(chan struct{})
+				release     = make(chan struct{})
+				multicaster = app.NewEventMulticaster(app.WithAsync(1), app.WithQueue(1, overflow))
+			)
+			a := RunTest(t, app.LogError, app.SetComponents(multicaster))
+			Subscribe(a, func(e *orderPlaced) error {
+				if e.id == "1" {
+					close(started)
+					<-release
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				ids = append(ids, e.id)
+				return nil
+			})
+			assert.NoError(t, a.PublishEvent(&orderPlaced{id: "1"}))
+			<-started // the worker is busy
+			assert.NoError(t, a.PublishEvent(&orderPlaced{id: "2"}))
+			assert.NoError(t, a.PublishEvent(&orderPlaced{id: "3"}))
+			delivered = func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+				return slices.Clone(ids)
+			}
+			return delivered, func() {
+				close(release)
+				assert.NoError(t, multicaster.(definition.CloserComponent).Close())
+			}
+		}
+		t.Run("Drop", func(t *testing.T) {
+			delivered, done := publish(t, app.DropOnOverflow)
+			done()
+			assert.Equal(t, []string{"1", "2"}, delivered())
+		})
+		t.Run("CallerRuns", func(t *testing.T) {
+			delivered, done := publish(t, app.CallerRunsOnOverflow)
+			assert.Equal(t, []string{"3"}, delivered())
+			done()
+			assert.Equal(t, []string{"3", "1", "2"}, delivered())
+		})
+	})
+}
+
+type recordingListener struct {
+	name  string
+	calls *[]string
+}
+
+func (l *recordingListener) OnEvent(e *orderPlaced) error {
+	*l.calls = append(*l.calls, l.name)
+	return nil
+}
+
+type orderedListener struct {
+	recordingListener
+	order int
+}
+
+func (l *orderedListener) Order() int { return l.order }
+
+type priorityListener struct {
+	orderedListener
+	definition.PriorityComponent
+}
+
+type largeOrderListener struct {
+	minAmount int
+	received  []string
+}
+
+type largeOrder struct {
+	orderPlaced
+	amount int
+}
+
+func (l *largeOrderListener) OnEvent(e orderEvent) error {
+	l.received = append(l.received, e.OrderID())
+	return nil
+}
+
+func (l *largeOrderListener) Condition(e orderEvent) bool {
+	large, ok := e.(*largeOrder)
+	return ok && large.amount >= l.minAmount
+}
+
+var _ definition.ConditionalEventListener[orderEvent] = (*largeOrderListener)(nil)
+
+func TestOrderedEventListeners(t *testing.T) {
+	var calls []string
+	a := RunTest(t, app.LogError,
+		app.SetNamedComponent("a", &recordingListener{name: "a", calls: &calls}),
+		app.SetNamedComponent("b", &orderedListener{recordingListener{name: "b", calls: &calls}, 2}),
+		app.SetNamedComponent("c", &orderedListener{recordingListener{name: "c", calls: &calls}, 1}),
+		app.SetNamedComponent("d", &priorityListener{orderedListener: orderedListener{recordingListener{name: "d", calls: &calls}, 3}}),
+		app.SetNamedComponent("e", &recordingListener{name: "e", calls: &calls}),
+	)
+	Subscribe(a, func(*orderPlaced) error {
+		calls = append(calls, "subscribed")
+		return nil
+	})
+	assert.NoError(t, a.PublishEvent(&orderPlaced{id: "1"}))
+	assert.Equal(t, []string{"d", "c", "b", "a", "e", "subscribed"}, calls)
+}
+
+func TestConditionalEventListeners(t *testing.T) {
+	listener := &largeOrderListener{minAmount: 100}
+	a := RunTest(t, app.LogError, app.SetComponents(listener))
+
+	assert.NoError(t, a.PublishEvent(&orderPlaced{id: "1"}))
+	assert.NoError(t, a.PublishEvent(&largeOrder{orderPlaced{id: "2"}, 50}))
+	assert.NoError(t, a.PublishEvent(&largeOrder{orderPlaced{id: "3"}, 150}))
+	assert.Equal(t, []string{"3"}, listener.received)
+}
+
+type phaseListener struct {
+	phase    definition.TransactionPhase
+	received []string
+}
+
+func (l *phaseListener) OnEvent(e *orderPlaced) error {
+	l.received = append(l.received, e.id)
+	return nil
+}
+
+func (l *phaseListener) TransactionPhase() definition.TransactionPhase { return l.phase }
+
+type orderService struct {
+	Publisher definition.ApplicationEventPublisherWithContext `wire:""`
+}
+
+func (s *orderService) PlaceOrder(ctx context.Context, ids ...string) error {
+	for _, id := range ids {
+		if err := s.Publisher.PublishEventWithContext(ctx, &orderPlaced{id: id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTransactionalEventListeners(t *testing.T) {
+	var (
+		placed     = &placedListener{}
+		committed  = &phaseListener{phase: definition.AfterCommit}
+		rolledBack = &phaseListener{phase: definition.AfterRollback}
+		completed  = &phaseListener{phase: definition.AfterCompletion}
+		service    = &orderService{}
+	)
+	a := RunTest(t, app.LogError, app.SetComponents(service, placed),
+		app.SetNamedComponent("committed", committed),
+		app.SetNamedComponent("rolledBack", rolledBack),
+		app.SetNamedComponent("completed", completed),
+	)
+
+	ctx := app.WithEventBuffer(context.Background())
+	assert.NoError(t, service.PlaceOrder(ctx, "1", "2"))
+	assert.Equal(t, []string{"1", "2"}, placed.received)
+	assert.Empty(t, committed.received)
+	assert.NoError(t, a.FlushEvents(ctx, true))
+	assert.Equal(t, []string{"1", "2"}, committed.received)
+	assert.Empty(t, rolledBack.received)
+	assert.ErrorContains(t, service.PlaceOrder(ctx, "3"), "after the unit of work ended")
+	assert.NoError(t, a.FlushEvents(ctx, true))
+
+	ctx = app.WithEventBuffer(context.Background())
+	assert.NoError(t, service.PlaceOrder(ctx, "4"))
+	assert.NoError(t, a.FlushEvents(ctx, false))
+	assert.Equal(t, []string{"4"}, rolledBack.received)
+	assert.Equal(t, []string{"1", "2", "4"}, completed.received)
+
+	assert.NoError(t, service.PlaceOrder(context.Background(), "5"))
+	assert.Equal(t, []string{"1", "2", "4"}, completed.received)
+	assert.Error(t, a.FlushEvents(context.Background(), true))
+}
@@ -0,0 +1,95 @@
+package ioc
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container"
+	"github.com/pkg/errors"
+)
+
+// Get returns the unique component assignable to T, T must be a pointer or an interface type.
+// Additional options (e.g. container.Qualifier) narrow down the candidates.
+// If several candidates remain, the single WirePrimary one is returned, otherwise an error listing all candidates.
+func Get[T any](factory container.Factory, opts ...container.Option) (T, error) {
+	var zero T
+	metas, err := lookupMetas[T](factory, opts)
+	if err != nil {
+		return zero, err
+	}
+	if len(metas) == 0 {
+		return zero, errors.WithMessage(&container.NoSuchComponentError{Type: typeOf[T]()}, "ioc.Get")
+	}
+	selected, err := component_definition.SelectUniqueCandidate(metas)
+	if err != nil {
+		var ambiguousErr *container.AmbiguousComponentError
+		if errors.As(err, &ambiguousErr) {
+			ambiguousErr.Type = typeOf[T]()
+		}
+		return zero, errors.WithMessagef(err, "ioc.Get: ambiguous components for type %s, mark one as primary or specify a qualifier", typeOf[T]())
+	}
+	return GetNamed[T](factory, selected.Name())
+}
+
+// MustGet is like Get but panics if the component can not be resolved
+func MustGet[T any](factory container.Factory, opts ...container.Option) T {
+	t, err := Get[T](factory, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// GetAll returns all components assignable to T sorted by component name, T must be a pointer or an interface type.
+func GetAll[T any](factory container.Factory, opts ...container.Option) ([]T, error) {
+	metas, err := lookupMetas[T](factory, opts)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(metas, func(a, b *component_definition.Meta) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	components := make([]T, 0, len(metas))
+	for _, m := range metas {
+		t, err := GetNamed[T](factory, m.Name())
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, t)
+	}
+	return components, nil
+}
+
+// GetNamed returns the component with the given name, it must be assignable to T
+func GetNamed[T any](factory container.Factory, name string) (T, error) {
+	var zero T
+	component, err := factory.GetComponentByName(name)
+	if err != nil {
+		return zero, errors.WithMessagef(err, "ioc.GetNamed: get component '%s'", name)
+	}
+	t, ok := component.(T)
+	if !ok {
+		return zero, errors.Errorf("ioc.GetNamed: component '%s' of type %T is not %s", name, component, typeOf[T]())
+	}
+	return t, nil
+}
+
+func lookupMetas[T any](factory container.Factory, opts []container.Option) ([]*component_definition.Meta, error) {
+	typ := typeOf[T]()
+	var typeOption container.Option
+	switch typ.Kind() {
+	case reflect.Ptr:
+		typeOption = container.Type(typ)
+	case reflect.Interface:
+		typeOption = container.InterfaceType(typ)
+	default:
+		return nil, errors.Errorf("ioc: unsupported lookup type %s, must be pointer or interface", typ)
+	}
+	return factory.GetDefinitionRegistry().GetMetas(append([]container.Option{typeOption}, opts...)...), nil
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
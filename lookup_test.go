@@ -0,0 +1,92 @@
+package ioc
+
+import (
+	"testing"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/definition"
+	"github.com/stretchr/testify/assert"
+)
+
+type lookupRepo interface {
+	Find() string
+}
+
+type lookupMySQL struct{}
+
+func (l *lookupMySQL) Find() string      { return "mysql" }
+func (l *lookupMySQL) Qualifier() string { return "sql" }
+
+type lookupRedis struct{}
+
+func (l *lookupRedis) Find() string      { return "redis" }
+func (l *lookupRedis) Qualifier() string { return "cache" }
+
+type lookupPrimaryRedis struct {
+	lookupRedis
+	definition.WirePrimaryComponent
+}
+
+type lookupService struct{}
+
+func TestGet(t *testing.T) {
+	svc := &lookupService{}
+	a := RunTest(t, app.SetComponents(svc, &lookupMySQL{}, &lookupRedis{}))
+
+	got, err := Get[*lookupService](a)
+	assert.NoError(t, err)
+	assert.Same(t, svc, got)
+	assert.Same(t, svc, MustGet[*lookupService](a))
+
+	_, err = Get[lookupRepo](a)
+	assert.ErrorContains(t, err, "ambiguous")
+	assert.ErrorContains(t, err, "lookupMySQL")
+	assert.ErrorContains(t, err, "lookupRedis")
+	assert.Panics(t, func() { MustGet[lookupRepo](a) })
+
+	repo, err := Get[lookupRepo](a, container.Qualifier("cache"))
+	assert.NoError(t, err)
+	assert.Equal(t, "redis", repo.Find())
+
+	_, err = Get[*lookupPrimaryRedis](a)
+	assert.ErrorContains(t, err, "no component found")
+
+	_, err = Get[lookupService](a)
+	assert.ErrorContains(t, err, "unsupported lookup type")
+}
+
+func TestGet_Primary(t *testing.T) {
+	a := RunTest(t, app.SetComponents(&lookupMySQL{}, &lookupPrimaryRedis{}))
+	repo, err := Get[lookupRepo](a)
+	assert.NoError(t, err)
+	assert.IsType(t, &lookupPrimaryRedis{}, repo)
+}
+
+func TestGetAll(t *testing.T) {
+	a := RunTest(t, app.SetComponents(&lookupMySQL{}, &lookupRedis{}))
+	repos, err := GetAll[lookupRepo](a)
+	assert.NoError(t, err)
+	assert.Len(t, repos, 2)
+	assert.Equal(t, "mysql", repos[0].Find())
+	assert.Equal(t, "redis", repos[1].Find())
+
+	repos, err = GetAll[lookupRepo](a, container.Qualifier("sql"))
+	assert.NoError(t, err)
+	assert.Len(t, repos, 1)
+}
+
+func TestGetNamed(t *testing.T) {
+	svc := &lookupService{}
+	a := RunTest(t, app.SetComponents(svc))
+	name := "github.com/go-kid/ioc/lookupService"
+	got, err := GetNamed[*lookupService](a, name)
+	assert.NoError(t, err)
+	assert.Same(t, svc, got)
+
+	_, err = GetNamed[lookupRepo](a, name)
+	assert.ErrorContains(t, err, "is not")
+
+	_, err = GetNamed[*lookupService](a, "not-exist")
+	assert.Error(t, err)
+}
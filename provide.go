@@ -3,6 +3,8 @@ package ioc
 import (
 	"fmt"
 	"reflect"
+
+	"github.com/go-kid/ioc/component_definition"
 )
 
 // Provide registers a typed constructor with compile-time return type verification.
@@ -41,3 +43,52 @@ func Provide[T any](constructor any) {
 
 	Register(constructor)
 }
+
+// ProvideWith registers a constructor whose parameters are annotated by Param,
+// the annotations share the semantics of the `wire` tag arguments of fields:
+//
+//	ioc.ProvideWith(NewService,
+//		ioc.Param(0).Named("primaryDB"),
+//		ioc.Param(1).Qualifier("cache"),
+//		ioc.Param(2).Optional())
+//
+// Panics at registration time if constructor is not a function or an annotated parameter does not exist.
+func ProvideWith(constructor any, params ...*component_definition.ConstructorParam) {
+	Register(Constructor(constructor, params...))
+}
+
+// ProvideNamed registers a constructor producing the component with name, instead of the name of its return type.
+// Together with method expressions it registers factory methods of a configuration component,
+// the receiver is resolved like any other parameter:
+//
+//	func (c *DBConfig) ReadDB() *sql.DB
+//	func (c *DBConfig) WriteDB() *sql.DB
+//
+//	ioc.Register(&DBConfig{})
+//	ioc.ProvideNamed("readDB", (*DBConfig).ReadDB)
+//	ioc.ProvideNamed("writeDB", (*DBConfig).WriteDB)
+func ProvideNamed(name string, constructor any, params ...*component_definition.ConstructorParam) {
+	if name == "" {
+		panic("ioc.ProvideNamed: name must not be empty")
+	}
+	Register(Constructor(constructor, params...).Named(name))
+}
+
+// Constructor annotates the parameters of constructor like ProvideWith without registering it,
+// the result can be registered by app.SetComponents.
+func Constructor(constructor any, params ...*component_definition.ConstructorParam) *component_definition.Constructor {
+	ct := reflect.TypeOf(constructor)
+	if ct == nil || ct.Kind() != reflect.Func {
+		panic("ioc.Constructor: constructor must be a function")
+	}
+	c := component_definition.NewConstructor(constructor, params...)
+	if err := c.ValidateParams(); err != nil {
+		panic(fmt.Sprintf("ioc.Constructor: %v", err))
+	}
+	return c
+}
+
+// Param starts the annotation of the constructor parameter at index (0-based)
+func Param(index int) *component_definition.ConstructorParam {
+	return component_definition.NewConstructorParam(index)
+}
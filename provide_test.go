@@ -84,3 +84,28 @@ func TestProvide_InterfaceNotImplemented(t *testing.T) {
 		})
 	})
 }
+
+func TestProvideWith_AnnotatedParams(t *testing.T) {
+	assert.NotPanics(t, func() {
+		ProvideWith(func(c *provideTestComponent) *provideTestImpl {
+			return &provideTestImpl{Val: c.Value}
+		}, Param(0).Named("component"))
+	})
+	registerHandlers = nil
+}
+
+func TestProvideWith_ParamOutOfRange(t *testing.T) {
+	assert.Panics(t, func() {
+		ProvideWith(func() *provideTestImpl {
+			return &provideTestImpl{}
+		}, Param(0).Optional())
+	})
+	registerHandlers = nil
+}
+
+func TestProvideNamed_EmptyName(t *testing.T) {
+	assert.PanicsWithValue(t, "ioc.ProvideNamed: name must not be empty", func() {
+		ProvideNamed("", func() *provideTestImpl { return &provideTestImpl{} })
+	})
+	registerHandlers = nil
+}
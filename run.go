@@ -3,12 +3,18 @@ package ioc
 import (
 	"context"
 	"flag"
+	"io"
+	"os"
+	"slices"
 
 	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/debug"
 	"github.com/go-kid/ioc/syslog"
 )
 
+const validateFlag = "--ioc:validate"
+
 var (
 	flagLogLevel     string
 	registerHandlers []app.SettingOption
@@ -22,11 +28,22 @@ func Register(cs ...interface{}) {
 	registerHandlers = append(registerHandlers, app.SetComponents(cs...))
 }
 
+// RegisterNamed registers a pre-built value under name, see app.SetNamedComponent
+func RegisterNamed(name string, c any, opts ...component_definition.RegistrationOption) {
+	registerHandlers = append(registerHandlers, app.SetNamedComponent(name, c, opts...))
+}
+
 func Run(ops ...app.SettingOption) (*app.App, error) {
 	return RunWithContext(context.Background(), ops...)
 }
 
 func RunWithContext(ctx context.Context, ops ...app.SettingOption) (*app.App, error) {
+	if slices.Contains(os.Args[1:], validateFlag) {
+		validateAndExit(ctx, ops)
+	}
+	if path, ok := debug.GraphFlag(); ok {
+		exportGraphAndExit(ctx, path, ops)
+	}
 	if debug.HasRunDebugFlag() {
 		return RunDebugWithContext(ctx, ops...)
 	}
@@ -45,6 +62,63 @@ func RunDebugWithContext(ctx context.Context, ops ...app.SettingOption) (*app.Ap
 	return doRun(ctx, ops, debugOps)
 }
 
+// ExportGraph refreshes the components in dry run mode, without calling init methods and runners,
+// and writes their dependency graph to w in format.
+func ExportGraph(w io.Writer, format debug.GraphFormat, ops ...app.SettingOption) error {
+	return ExportGraphWithContext(context.Background(), w, format, ops...)
+}
+
+func ExportGraphWithContext(ctx context.Context, w io.Writer, format debug.GraphFormat, ops ...app.SettingOption) error {
+	graphOps, graph := debug.SetupGraph()
+	if _, err := doRun(ctx, ops, graphOps); err != nil {
+		return err
+	}
+	return debug.WriteGraph(w, graph(), format)
+}
+
+// exportGraphAndExit handles the --ioc:graph=<file> CLI flag, the format is chosen by the file extension
+func exportGraphAndExit(ctx context.Context, path string, ops []app.SettingOption) {
+	logger := syslog.Pref("Graph")
+	file, err := os.Create(path)
+	if err != nil {
+		logger.Fatalf("create graph file: %v", err)
+	}
+	err = ExportGraphWithContext(ctx, file, debug.GraphFormatOf(path), ops...)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logger.Fatalf("export component graph: %+v", err)
+	}
+	logger.Infof("component graph written to %s", path)
+	os.Exit(0)
+}
+
+// Validate checks the wiring of the registered components without creating them, see app.App.Validate
+func Validate(ops ...app.SettingOption) error {
+	return ValidateWithContext(context.Background(), ops...)
+}
+
+func ValidateWithContext(ctx context.Context, ops ...app.SettingOption) error {
+	s := app.NewApp()
+	if flagLogLevel != "" {
+		syslog.Level(syslog.NewLvFromString(flagLogLevel))
+	}
+	allOps := append(ops, registerHandlers...)
+	registerHandlers = nil
+	return s.ValidateWithContext(ctx, allOps...)
+}
+
+// validateAndExit handles the --ioc:validate CLI flag, the process exits with status 1 if the wiring is invalid
+func validateAndExit(ctx context.Context, ops []app.SettingOption) {
+	logger := syslog.Pref("Validate")
+	if err := ValidateWithContext(ctx, ops...); err != nil {
+		logger.Fatalf("%v", err)
+	}
+	logger.Info("component wiring is valid")
+	os.Exit(0)
+}
+
 func doRun(ctx context.Context, ops []app.SettingOption, extra []app.SettingOption) (*app.App, error) {
 	s := app.NewApp()
 	if flagLogLevel != "" {
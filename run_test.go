@@ -0,0 +1,115 @@
+package ioc
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/debug"
+	"github.com/go-kid/ioc/definition"
+	"github.com/stretchr/testify/assert"
+)
+
+type graphTestRepository interface {
+	Find() string
+}
+
+type graphTestStore struct{}
+
+func (s *graphTestStore) Naming() string { return "graphTestStore" }
+
+func (s *graphTestStore) Find() string { return "" }
+
+type graphTestCache struct {
+	initialized bool
+}
+
+func (c *graphTestCache) Naming() string { return "graphTestCache" }
+
+func (c *graphTestCache) Scope() string { return definition.ScopePrototype }
+
+func (c *graphTestCache) Init() error {
+	c.initialized = true
+	return nil
+}
+
+type graphTestReport struct{}
+
+func (r *graphTestReport) Naming() string { return "graphTestReport" }
+
+func (r *graphTestReport) LazyInit() {}
+
+type graphTestClient struct {
+	cache *graphTestCache
+}
+
+func newGraphTestClient(cache *graphTestCache) *graphTestClient {
+	return &graphTestClient{cache: cache}
+}
+
+type graphTestService struct {
+	Repository graphTestRepository `wire:""`
+	Client     *graphTestClient    `wire:""`
+}
+
+func (s *graphTestService) Naming() string { return "graphTestService" }
+
+func graphTestOptions() app.SettingOption {
+	return app.SetComponents(&graphTestService{}, &graphTestStore{}, &graphTestCache{}, &graphTestReport{},
+		Constructor(newGraphTestClient).Named("graphTestClient"))
+}
+
+func TestExportGraph(t *testing.T) {
+	//the components are named explicitly, the debug hooks leave out the components of this module by their names
+	const (
+		service = "graphTestService"
+		store   = "graphTestStore"
+		client  = "graphTestClient"
+		cache   = "graphTestCache"
+		report  = "graphTestReport"
+	)
+	t.Run("DOT", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, ExportGraph(&buf, debug.GraphFormatDOT, app.LogError, graphTestOptions()))
+		dot := buf.String()
+		assert.Contains(t, dot, "digraph components {")
+		assert.Contains(t, dot, `"`+service+`" -> "`+store+`" [label="Repository", style=dashed, tooltip="interface"];`)
+		assert.Contains(t, dot, `"`+service+`" -> "`+client+`" [label="Client"];`)
+		assert.Contains(t, dot, `"`+client+`" -> "`+cache+`" [label="param[0]"];`)
+		assert.Contains(t, dot, `"`+cache+`" [label="`+cache+`\nprototype"];`)
+		assert.Contains(t, dot, `"`+report+`" [label="`+report+`\nsingleton, lazy", style=dashed];`)
+	})
+	t.Run("Mermaid", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, ExportGraph(&buf, debug.GraphFormatMermaid, app.LogError, graphTestOptions()))
+		assert.Contains(t, buf.String(), "graph LR\n")
+		assert.Contains(t, buf.String(), `-.->|"Repository"|`)
+		assert.Contains(t, buf.String(), `-->|"param[0]"|`)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, ExportGraph(&buf, debug.GraphFormatJSON, app.LogError, graphTestOptions()))
+		var graph debug.GraphData
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &graph))
+		assert.Len(t, graph.Nodes, 5)
+		assert.Equal(t, []debug.DependencyEdge{
+			{From: client, To: cache, FieldName: "param[0]", DepType: "pointer"},
+			{From: service, To: client, FieldName: "Client", DepType: "pointer"},
+			{From: service, To: store, FieldName: "Repository", DepType: "interface"},
+		}, graph.Edges)
+	})
+	t.Run("Stable", func(t *testing.T) {
+		var first, second bytes.Buffer
+		assert.NoError(t, ExportGraph(&first, debug.GraphFormatDOT, app.LogError, graphTestOptions()))
+		assert.NoError(t, ExportGraph(&second, debug.GraphFormatDOT, app.LogError, graphTestOptions()))
+		assert.Equal(t, first.String(), second.String())
+	})
+}
+
+func TestGraphFormatOf(t *testing.T) {
+	assert.Equal(t, debug.GraphFormatDOT, debug.GraphFormatOf("deps.dot"))
+	assert.Equal(t, debug.GraphFormatDOT, debug.GraphFormatOf("deps.gv"))
+	assert.Equal(t, debug.GraphFormatMermaid, debug.GraphFormatOf("docs/deps.mmd"))
+	assert.Equal(t, debug.GraphFormatJSON, debug.GraphFormatOf("deps.JSON"))
+}
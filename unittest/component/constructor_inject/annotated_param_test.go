@@ -0,0 +1,90 @@
+package constructor_inject
+
+import (
+	"testing"
+
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+)
+
+type qualifiedService struct {
+	serviceImpl
+	qualifier string
+}
+
+func (q *qualifiedService) Qualifier() string { return q.qualifier }
+
+type annotatedComponent struct {
+	db       *DepA
+	cache    IService
+	optional *noArgComponent
+}
+
+func NewAnnotatedComponent(db *DepA, cache IService, optional *noArgComponent) *annotatedComponent {
+	return &annotatedComponent{db: db, cache: cache, optional: optional}
+}
+
+func TestConstructor_AnnotatedParams(t *testing.T) {
+	type T struct {
+		C *annotatedComponent `wire:""`
+	}
+	tt := &T{}
+	ioc.RunTest(t, app.SetComponents(tt,
+		&DepA{Name: "primaryDB"},
+		&DepA{Name: "secondaryDB"},
+		&primaryService{serviceImpl{id: "primary"}},
+		&qualifiedService{serviceImpl: serviceImpl{id: "redis"}, qualifier: "cache"},
+		ioc.Constructor(NewAnnotatedComponent,
+			ioc.Param(0).Named("primaryDB"),
+			ioc.Param(1).Qualifier("cache"),
+			ioc.Param(2).Optional()),
+	))
+	assert.Equal(t, "primaryDB", tt.C.db.Name)
+	assert.Equal(t, "redis", tt.C.cache.Serve())
+	assert.Nil(t, tt.C.optional)
+}
+
+func TestConstructor_AnnotatedParamsByWireTag(t *testing.T) {
+	type T struct {
+		C *ifaceDepComponent `wire:""`
+	}
+	tt := &T{}
+	ioc.RunTest(t, app.SetComponents(tt,
+		&primaryService{serviceImpl{id: "primary"}},
+		&qualifiedService{serviceImpl: serviceImpl{id: "redis"}, qualifier: "cache"},
+		ioc.Constructor(NewIfaceDepComponent, ioc.Param(0).Wire(",qualifier=cache")),
+	))
+	assert.Equal(t, "redis", tt.C.svc.Serve())
+}
+
+func TestConstructor_NamedParamNotFound(t *testing.T) {
+	ioc.RunErrorTest(t, app.SetComponents(
+		&DepA{Name: "secondaryDB"},
+		ioc.Constructor(NewPtrDepComponent, ioc.Param(0).Named("primaryDB")),
+	))
+}
+
+func TestConstructor_OptionalNamedParam(t *testing.T) {
+	type T struct {
+		C *ptrDepComponent `wire:""`
+	}
+	tt := &T{}
+	ioc.RunTest(t, app.SetComponents(tt,
+		&DepA{Name: "secondaryDB"},
+		ioc.Constructor(NewPtrDepComponent, ioc.Param(0).Named("primaryDB").Optional()),
+	))
+	assert.Nil(t, tt.C.dep)
+}
+
+func TestConstructor_AnnotatedParamOutOfRange(t *testing.T) {
+	assert.Panics(t, func() {
+		ioc.Constructor(NewPtrDepComponent, ioc.Param(1).Named("primaryDB"))
+	})
+}
+
+func TestConstructor_AnnotatedParamTwice(t *testing.T) {
+	assert.Panics(t, func() {
+		ioc.Constructor(NewPtrDepComponent, ioc.Param(0).Named("primaryDB"), ioc.Param(0).Optional())
+	})
+}
@@ -0,0 +1,208 @@
+package constructor_inject
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/definition"
+	"github.com/stretchr/testify/assert"
+)
+
+type cycleA struct{ b *cycleB }
+
+type cycleB struct{ c *cycleC }
+
+type cycleC struct{ a *cycleA }
+
+func newCycleA(b *cycleB) *cycleA { return &cycleA{b: b} }
+
+func newCycleB(c *cycleC) *cycleB { return &cycleB{c: c} }
+
+func newCycleC(a *cycleA) *cycleC { return &cycleC{a: a} }
+
+type mixedCycleClient struct{ repo *mixedCycleRepo }
+
+type mixedCycleRepo struct {
+	Client *mixedCycleClient `wire:""`
+}
+
+func newMixedCycleClient(repo *mixedCycleRepo) *mixedCycleClient {
+	return &mixedCycleClient{repo: repo}
+}
+
+type fieldCycleA struct {
+	B *fieldCycleB `wire:""`
+}
+
+type fieldCycleB struct {
+	A *fieldCycleA `wire:""`
+}
+
+type prototypeCycle struct {
+	Peer *prototypePeer `wire:""`
+}
+
+func (p *prototypeCycle) Scope() string { return definition.ScopePrototype }
+
+type prototypePeer struct {
+	Cycle *prototypeCycle `wire:""`
+}
+
+func (p *prototypePeer) Scope() string { return definition.ScopePrototype }
+
+type prototypeCycleHolder struct {
+	Cycle *prototypeCycle `wire:""`
+}
+
+// parallelCycleBarrier holds the constructors of parallelCycleX and parallelCycleY until both are called,
+// so that each of them is created by its own worker
+var parallelCycleBarrier struct {
+	sync.WaitGroup
+	arrivals atomic.Int32
+}
+
+func awaitParallelCycle() {
+	// the failed components are created again by the disallowed case, only the first calls are counted
+	if parallelCycleBarrier.arrivals.Add(1) <= 2 {
+		parallelCycleBarrier.Done()
+	}
+	parallelCycleBarrier.Wait()
+}
+
+type parallelCycleX struct {
+	Link *parallelCycleXLink `wire:""`
+}
+
+// parallelCycleXLink is lazy, the dependency graph of the parallel refresh does not see the cycle through it
+type parallelCycleXLink struct {
+	Y *parallelCycleY `wire:""`
+}
+
+func (l *parallelCycleXLink) LazyInit() {}
+
+type parallelCycleY struct {
+	Link *parallelCycleYLink `wire:""`
+}
+
+type parallelCycleYLink struct {
+	X *parallelCycleX `wire:""`
+}
+
+func (l *parallelCycleYLink) LazyInit() {}
+
+func newParallelCycleX() *parallelCycleX {
+	awaitParallelCycle()
+	return &parallelCycleX{}
+}
+
+func newParallelCycleY() *parallelCycleY {
+	awaitParallelCycle()
+	return &parallelCycleY{}
+}
+
+func parallelCycleOptions() app.SettingOption {
+	parallelCycleBarrier.arrivals.Store(0)
+	parallelCycleBarrier.Add(2)
+	return app.Options(app.LogError, app.ParallelRefresh(2),
+		app.SetComponents(newParallelCycleX, newParallelCycleY, &parallelCycleXLink{}, &parallelCycleYLink{}))
+}
+
+// assertParallelCycle checks the cycle between the workers, it starts from either of them
+func assertParallelCycle(t *testing.T, ref container.CircularReference) {
+	if assert.Len(t, ref.Cycle, 5) && assert.Len(t, ref.Via, 4) {
+		assert.Equal(t, ref.Cycle[0], ref.Cycle[4])
+		var names []string
+		for _, name := range ref.Cycle[:4] {
+			names = append(names, name[strings.LastIndex(name, "/")+1:])
+		}
+		assert.ElementsMatch(t, []string{"parallelCycleX", "parallelCycleXLink", "parallelCycleY", "parallelCycleYLink"}, names)
+		assert.ElementsMatch(t, []string{"Link", "Y", "Link", "X"}, ref.Via)
+	}
+}
+
+func circularReferenceOf(t *testing.T, err error) *container.CircularReferenceError {
+	var circularErr *container.CircularReferenceError
+	if !assert.True(t, errors.As(err, &circularErr)) {
+		return nil
+	}
+	for i, name := range circularErr.Cycle {
+		circularErr.Cycle[i] = name[strings.LastIndex(name, "/")+1:]
+	}
+	return circularErr
+}
+
+func TestCircularReference(t *testing.T) {
+	t.Run("Constructors", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.SetComponents(newCycleA, newCycleB, newCycleC))
+		if circularErr := circularReferenceOf(t, err); circularErr != nil {
+			assert.Equal(t, []string{"cycleA", "cycleB", "cycleC", "cycleA"}, circularErr.Cycle)
+			assert.Equal(t, []string{"param[0]", "param[0]", "param[0]"}, circularErr.Via)
+			assert.Equal(t, "cycleA (param[0]) -> cycleB (param[0]) -> cycleC (param[0]) -> cycleA", circularErr.Path())
+		}
+	})
+	t.Run("ConstructorAndField", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.SetComponents(&mixedCycleRepo{}, newMixedCycleClient))
+		if circularErr := circularReferenceOf(t, err); circularErr != nil {
+			assert.Equal(t, []string{"mixedCycleClient", "mixedCycleRepo", "mixedCycleClient"}, circularErr.Cycle)
+			assert.Equal(t, []string{"param[0]", "Client"}, circularErr.Via)
+		}
+	})
+	t.Run("Prototypes", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.SetComponents(&prototypeCycleHolder{}, &prototypeCycle{}, &prototypePeer{}))
+		if circularErr := circularReferenceOf(t, err); circularErr != nil {
+			assert.Equal(t, []string{"prototypeCycle", "prototypePeer", "prototypeCycle"}, circularErr.Cycle)
+			assert.Equal(t, []string{"Peer", "Cycle"}, circularErr.Via)
+		}
+	})
+	t.Run("FieldsAllowed", func(t *testing.T) {
+		a, b := &fieldCycleA{}, &fieldCycleB{}
+		s := app.NewApp()
+		assert.NoError(t, s.Run(app.LogError, app.SetComponents(a, b)))
+		assert.Same(t, b, a.B)
+		assert.Same(t, a, b.A)
+
+		refs := s.CircularReferences()
+		if assert.Len(t, refs, 1) {
+			assert.Equal(t, []string{"B", "A"}, refs[0].Via)
+			assert.True(t, strings.HasSuffix(refs[0].Path(), "fieldCycleA"))
+		}
+	})
+	t.Run("FieldsDisallowed", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.DisallowCircularReferences(), app.SetComponents(&fieldCycleA{}, &fieldCycleB{}))
+		if circularErr := circularReferenceOf(t, err); circularErr != nil {
+			assert.Equal(t, []string{"fieldCycleA", "fieldCycleB", "fieldCycleA"}, circularErr.Cycle)
+			assert.Equal(t, []string{"B", "A"}, circularErr.Via)
+		}
+	})
+	t.Run("ParallelAllowed", func(t *testing.T) {
+		s := app.NewApp()
+		assert.NoError(t, s.Run(parallelCycleOptions()))
+		refs := s.CircularReferences()
+		if assert.Len(t, refs, 1) {
+			assertParallelCycle(t, refs[0])
+		}
+	})
+	t.Run("ParallelDisallowed", func(t *testing.T) {
+		err := app.NewApp().Run(parallelCycleOptions(), app.DisallowCircularReferences())
+		var circularErr *container.CircularReferenceError
+		if assert.True(t, errors.As(err, &circularErr)) {
+			assertParallelCycle(t, circularErr.CircularReference)
+		}
+	})
+	t.Run("ValidateDisallowed", func(t *testing.T) {
+		err := app.NewApp().Validate(app.LogError, app.SetComponents(&fieldCycleA{}, &fieldCycleB{}))
+		assert.NoError(t, err)
+
+		err = app.NewApp().Validate(app.LogError, app.DisallowCircularReferences(), app.SetComponents(&fieldCycleA{}, &fieldCycleB{}))
+		var validationErr *container.ValidationError
+		if assert.True(t, errors.As(err, &validationErr)) && assert.Len(t, validationErr.Problems, 1) {
+			assert.Equal(t, container.ProblemCycle, validationErr.Problems[0].Kind)
+			assert.Contains(t, validationErr.Problems[0].Reason, "disallowed")
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package constructor_inject
+
+import (
+	"testing"
+
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+type dbPool struct {
+	dsn string
+}
+
+type dbPoolConfig struct {
+	ReadDSN  string `prop:"db.read"`
+	WriteDSN string `prop:"db.write"`
+}
+
+func (c *dbPoolConfig) ReadDB() *dbPool {
+	return &dbPool{dsn: c.ReadDSN}
+}
+
+func (c *dbPoolConfig) WriteDB() (*dbPool, error) {
+	return &dbPool{dsn: c.WriteDSN}, nil
+}
+
+type repository struct {
+	Read  *dbPool   `wire:"readDB"`
+	Write *dbPool   `wire:"writeDB"`
+	All   []*dbPool `wire:""`
+}
+
+func TestConstructor_FactoryMethods(t *testing.T) {
+	repo := &repository{}
+	ioc.RunTest(t,
+		app.SetComponents(repo, &dbPoolConfig{},
+			ioc.Constructor((*dbPoolConfig).ReadDB).Named("readDB"),
+			ioc.Constructor((*dbPoolConfig).WriteDB).Named("writeDB"),
+		),
+		app.SetConfigLoader(loader.NewRawLoader([]byte(`
+db:
+  read: "postgres://replica"
+  write: "postgres://primary"
+`))),
+	)
+	assert.Equal(t, "postgres://replica", repo.Read.dsn)
+	assert.Equal(t, "postgres://primary", repo.Write.dsn)
+	assert.Len(t, repo.All, 2)
+}
+
+func TestConstructor_NamedConstructorsOfSameType(t *testing.T) {
+	type T struct {
+		First  *DepA `wire:"first"`
+		Second *DepA `wire:"second"`
+	}
+	tt := &T{}
+	ioc.RunTest(t, app.SetComponents(tt,
+		ioc.Constructor(func() *DepA { return &DepA{Name: "1"} }).Named("first"),
+		ioc.Constructor(func() *DepA { return &DepA{Name: "2"} }).Named("second"),
+	))
+	assert.Equal(t, "1", tt.First.Name)
+	assert.Equal(t, "2", tt.Second.Name)
+}
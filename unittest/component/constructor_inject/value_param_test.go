@@ -0,0 +1,87 @@
+package constructor_inject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+type valueParamComponent struct {
+	dsn     string
+	timeout time.Duration
+	retries int
+	weight  float64
+	hosts   []string
+	dep     *DepA
+}
+
+func NewValueParamComponent(dsn string, timeout time.Duration, retries int, weight float64, hosts []string, dep *DepA) *valueParamComponent {
+	return &valueParamComponent{dsn: dsn, timeout: timeout, retries: retries, weight: weight, hosts: hosts, dep: dep}
+}
+
+type dsnComponent struct {
+	dsn string
+}
+
+func NewDsnComponent(dsn string) *dsnComponent {
+	return &dsnComponent{dsn: dsn}
+}
+
+var valueParamConfig = []byte(`
+db:
+  dsn: "postgres://localhost"
+  timeout: 3s
+  hosts:
+    - a
+    - b
+`)
+
+func TestConstructor_ValueParams(t *testing.T) {
+	type T struct {
+		C *valueParamComponent `wire:""`
+	}
+	tt := &T{}
+	ioc.RunTest(t,
+		app.SetComponents(tt, &DepA{Name: "dep"}, ioc.Constructor(NewValueParamComponent,
+			ioc.Param(0).Prop("db.dsn"),
+			ioc.Param(1).Value("${db.timeout}"),
+			ioc.Param(2).Value("${db.retries:5}"),
+			ioc.Param(3).Value("#{${db.retries:5} * 1.5}"),
+			ioc.Param(4).Prop("db.hosts"),
+		)),
+		app.SetConfigLoader(loader.NewRawLoader(valueParamConfig)),
+	)
+	assert.Equal(t, "postgres://localhost", tt.C.dsn)
+	assert.Equal(t, 3*time.Second, tt.C.timeout)
+	assert.Equal(t, 5, tt.C.retries)
+	assert.Equal(t, 7.5, tt.C.weight)
+	assert.Equal(t, []string{"a", "b"}, tt.C.hosts)
+	assert.Equal(t, "dep", tt.C.dep.Name)
+}
+
+func TestConstructor_ValueParamRequired(t *testing.T) {
+	ioc.RunErrorTest(t,
+		app.SetComponents(ioc.Constructor(NewDsnComponent, ioc.Param(0).Prop("db.missing"))),
+		app.SetConfigLoader(loader.NewRawLoader(valueParamConfig)),
+	)
+}
+
+func TestConstructor_ValueParamOptional(t *testing.T) {
+	type T struct {
+		C *dsnComponent `wire:""`
+	}
+	tt := &T{}
+	ioc.RunTest(t,
+		app.SetComponents(tt, ioc.Constructor(NewDsnComponent, ioc.Param(0).Prop("db.missing,required=false"))),
+		app.SetConfigLoader(loader.NewRawLoader(valueParamConfig)),
+	)
+	assert.Equal(t, "", tt.C.dsn)
+}
+
+func TestConstructor_UnboundPrimitiveParam(t *testing.T) {
+	ioc.RunErrorTest(t, app.SetComponents(NewDsnComponent))
+}
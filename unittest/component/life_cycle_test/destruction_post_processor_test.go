@@ -0,0 +1,45 @@
+package life_cycle_test
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container/processors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type destructionAwarePostProcessor struct {
+	processors.DefaultComponentPostProcessor
+	destroyed []string
+	closed    func() bool
+}
+
+func (d *destructionAwarePostProcessor) PostProcessBeforeDestruction(component any, componentName string) error {
+	if d.closed() {
+		return nil
+	}
+	d.destroyed = append(d.destroyed, component.(*CloserComponent).Name)
+	return nil
+}
+
+func (d *destructionAwarePostProcessor) RequireDestruction(component any) bool {
+	_, ok := component.(*CloserComponent)
+	return ok
+}
+
+func TestDestructionAwarePostProcessor(t *testing.T) {
+	var (
+		a = &CloserComponent{StateComponent{NamingComponent: NamingComponent{"a"}}}
+		b = &CloserComponent{StateComponent{NamingComponent: NamingComponent{"b"}}}
+		p = &destructionAwarePostProcessor{}
+	)
+	p.closed = func() bool {
+		return a.State == 2 || b.State == 2
+	}
+	application := ioc.RunTest(t, app.SetComponents(a, b, p))
+	assert.Empty(t, p.destroyed)
+	application.Close()
+	assert.ElementsMatch(t, []string{"a", "b"}, p.destroyed)
+	assert.Equal(t, 2, a.State)
+	assert.Equal(t, 2, b.State)
+}
@@ -0,0 +1,124 @@
+package life_cycle_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/definition"
+	"github.com/stretchr/testify/assert"
+)
+
+type lazyReporter interface {
+	Report() string
+}
+
+type lazyReport struct {
+	definition.LazyInitComponent
+	inits *atomic.Int32
+	fail  *atomic.Bool
+}
+
+func (r *lazyReport) Init() error {
+	if r.fail.Load() {
+		return errors.New("report storage unavailable")
+	}
+	r.inits.Add(1)
+	return nil
+}
+
+func (r *lazyReport) Report() string { return "report" }
+
+type lazyHandler struct {
+	Report   container.Lazy[*lazyReport]  `wire:""`
+	Reporter container.Lazy[lazyReporter] `wire:""`
+}
+
+// lazyCycleA is created by its constructor in a field cycle with lazyCycleB, its Init fails while fail is set
+type lazyCycleA struct {
+	definition.LazyInitComponent
+	B    *lazyCycleB `wire:""`
+	fail *atomic.Bool
+}
+
+func (a *lazyCycleA) Init() error {
+	if a.fail.Load() {
+		return errors.New("cycle storage unavailable")
+	}
+	return nil
+}
+
+type lazyCycleB struct {
+	definition.LazyInitComponent
+	A *lazyCycleA `wire:""`
+}
+
+type lazyCycleHandler struct {
+	A container.Lazy[*lazyCycleA] `wire:""`
+}
+
+func TestLazy(t *testing.T) {
+	newReport := func() *lazyReport {
+		return &lazyReport{inits: &atomic.Int32{}, fail: &atomic.Bool{}}
+	}
+	t.Run("CreatedOnFirstGet", func(t *testing.T) {
+		report, handler := newReport(), &lazyHandler{}
+		assert.NoError(t, app.NewApp().Run(app.LogError, app.SetComponents(report, handler)))
+		assert.Zero(t, report.inits.Load())
+		assert.False(t, handler.Report.Resolved())
+
+		r, err := handler.Report.Get()
+		assert.NoError(t, err)
+		assert.Same(t, report, r)
+		assert.True(t, handler.Report.Resolved())
+		assert.Equal(t, "report", handler.Reporter.MustGet().Report())
+		assert.Equal(t, int32(1), report.inits.Load())
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		report, handlers := newReport(), []*lazyHandler{{}, {}}
+		assert.NoError(t, app.NewApp().Run(app.LogError, app.SetComponents(report, handlers[0]), app.SetNamedComponent("second", handlers[1])))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func(h *lazyHandler) {
+				defer wg.Done()
+				assert.Same(t, report, h.Report.MustGet())
+			}(handlers[i%2])
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), report.inits.Load())
+	})
+	t.Run("ErrorRetried", func(t *testing.T) {
+		report, handler := newReport(), &lazyHandler{}
+		assert.NoError(t, app.NewApp().Run(app.LogError, app.SetComponents(report, handler)))
+
+		report.fail.Store(true)
+		_, err := handler.Report.Get()
+		assert.ErrorContains(t, err, "report storage unavailable")
+		assert.False(t, handler.Report.Resolved())
+
+		report.fail.Store(false)
+		r, err := handler.Report.Get()
+		assert.NoError(t, err)
+		assert.Same(t, report, r)
+		assert.Equal(t, int32(1), report.inits.Load())
+	})
+	t.Run("ErrorRetriedInCycle", func(t *testing.T) {
+		fail, handler := &atomic.Bool{}, &lazyCycleHandler{}
+		newA := func() *lazyCycleA { return &lazyCycleA{fail: fail} }
+		assert.NoError(t, app.NewApp().Run(app.LogError, app.SetComponents(newA, &lazyCycleB{}, handler)))
+
+		fail.Store(true)
+		_, err := handler.A.Get()
+		assert.ErrorContains(t, err, "cycle storage unavailable")
+
+		fail.Store(false)
+		a, err := handler.A.Get()
+		assert.NoError(t, err)
+		assert.Same(t, a, a.B.A, "the holder of the failed early reference is created again")
+	})
+}
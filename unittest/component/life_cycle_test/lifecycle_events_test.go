@@ -0,0 +1,128 @@
+package life_cycle_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/definition"
+	"github.com/stretchr/testify/assert"
+)
+
+type lifecycleRecorder struct {
+	mu     sync.Mutex
+	events []definition.ApplicationEvent
+}
+
+func (r *lifecycleRecorder) OnEvent(event definition.ApplicationEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+// sequence returns the published events in order, the component events are kept for the component named name only
+func (r *lifecycleRecorder) sequence(name string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sequence []string
+	for _, event := range r.events {
+		switch e := event.(type) {
+		case *definition.ComponentCreatedEvent:
+			if e.ComponentName == name {
+				sequence = append(sequence, "created")
+			}
+		case *definition.ComponentInitializedEvent:
+			if e.ComponentName == name {
+				sequence = append(sequence, "initialized")
+			}
+		default:
+			sequence = append(sequence, fmt.Sprintf("%T", event))
+		}
+	}
+	return sequence
+}
+
+func lifecycleEvent[E definition.ApplicationEvent](r *lifecycleRecorder, match func(E) bool) (E, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, event := range r.events {
+		if e, ok := event.(E); ok && match(e) {
+			return e, true
+		}
+	}
+	var zero E
+	return zero, false
+}
+
+type lifecycleService struct{}
+
+func (s *lifecycleService) Naming() string { return "lifecycle-service" }
+
+func (s *lifecycleService) Close() error { return nil }
+
+func TestLifecycleEvents(t *testing.T) {
+	t.Run("RunAndClose", func(t *testing.T) {
+		recorder, service := &lifecycleRecorder{}, &lifecycleService{}
+		runner := &RunnerComponent{run: func() error { return nil }}
+		a := app.NewApp()
+		assert.NoError(t, a.Run(app.LogError, app.SetComponents(recorder, service, runner)))
+		a.Close()
+
+		assert.Equal(t, []string{
+			"*definition.ConfigurationLoadedEvent",
+			"created",
+			"initialized",
+			"*definition.RefreshFinishedEvent",
+			"*definition.RunnersStartedEvent",
+			"*definition.ApplicationStartedEvent",
+			"*definition.ApplicationReadyEvent",
+			"*definition.ApplicationClosingEvent",
+			"*definition.CloserFinishedEvent",
+			"*definition.ApplicationClosedEvent",
+		}, recorder.sequence("lifecycle-service"))
+
+		initialized, ok := lifecycleEvent(recorder, func(e *definition.ComponentInitializedEvent) bool {
+			return e.ComponentName == "lifecycle-service"
+		})
+		if assert.True(t, ok) {
+			assert.Same(t, service, initialized.Component)
+			assert.Equal(t, definition.ScopeSingleton, initialized.Scope)
+			assert.Equal(t, "*life_cycle_test.lifecycleService", initialized.Type.String())
+		}
+		refreshed, _ := lifecycleEvent(recorder, func(*definition.RefreshFinishedEvent) bool { return true })
+		assert.Positive(t, refreshed.Components)
+		assert.Positive(t, refreshed.Elapsed)
+		runners, _ := lifecycleEvent(recorder, func(*definition.RunnersStartedEvent) bool { return true })
+		assert.Equal(t, 1, runners.Runners)
+		closed, ok := lifecycleEvent(recorder, func(e *definition.CloserFinishedEvent) bool { return e.Closer == service })
+		assert.True(t, ok)
+		assert.NoError(t, closed.Err)
+	})
+	t.Run("RunnerFailed", func(t *testing.T) {
+		recorder := &lifecycleRecorder{}
+		runner := &RunnerComponent{run: func() error { return errors.New("migration failed") }}
+		assert.Error(t, app.NewApp().Run(app.LogError, app.SetComponents(recorder, runner)))
+
+		sequence := recorder.sequence("")
+		assert.Equal(t, "*definition.RunnerFailedEvent", sequence[len(sequence)-1])
+		failed, _ := lifecycleEvent(recorder, func(*definition.RunnerFailedEvent) bool { return true })
+		assert.Same(t, runner, failed.Runner)
+		assert.EqualError(t, failed.Err, "migration failed")
+		assert.False(t, slices.Contains(sequence, "*definition.ApplicationReadyEvent"))
+	})
+	t.Run("RunnersSkipped", func(t *testing.T) {
+		recorder, ran := &lifecycleRecorder{}, false
+		runner := &RunnerComponent{run: func() error { ran = true; return nil }}
+		assert.NoError(t, app.NewApp().Run(app.LogError, app.SkipRunners(), app.SetComponents(recorder, runner)))
+
+		assert.False(t, ran)
+		runners, ok := lifecycleEvent(recorder, func(*definition.RunnersStartedEvent) bool { return true })
+		if assert.True(t, ok) {
+			assert.Zero(t, runners.Runners)
+		}
+	})
+}
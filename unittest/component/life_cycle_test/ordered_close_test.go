@@ -0,0 +1,128 @@
+package life_cycle_test
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type closeRecorder struct {
+	mu     sync.Mutex
+	closed []string
+}
+
+func (r *closeRecorder) record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = append(r.closed, name)
+}
+
+func (r *closeRecorder) indexOf(name string) int {
+	for i, s := range r.closed {
+		if s == name {
+			return i
+		}
+	}
+	return -1
+}
+
+type Repository struct {
+	recorder *closeRecorder
+}
+
+func (r *Repository) Close() error {
+	r.recorder.record("repository")
+	return nil
+}
+
+type Cache struct {
+	recorder *closeRecorder
+}
+
+func (c *Cache) Close() error {
+	r := c.recorder
+	r.record("cache")
+	return nil
+}
+
+// Dao is not a closer, the service depends on the repository transitively through it
+type Dao struct {
+	Repository *Repository `wire:""`
+}
+
+type Service struct {
+	recorder *closeRecorder
+	Dao      *Dao   `wire:""`
+	Cache    *Cache `wire:""`
+}
+
+func (s *Service) Close() error {
+	// slow close, dependencies must wait for it
+	time.Sleep(20 * time.Millisecond)
+	s.recorder.record("service")
+	return nil
+}
+
+type Handler struct {
+	recorder *closeRecorder
+	Service  *Service `wire:""`
+}
+
+func (h *Handler) Close() error {
+	h.recorder.record("handler")
+	return nil
+}
+
+type CycleA struct {
+	recorder *closeRecorder
+	B        *CycleB `wire:""`
+}
+
+func (a *CycleA) Close() error {
+	a.recorder.record("a")
+	return nil
+}
+
+type CycleB struct {
+	recorder *closeRecorder
+	A        *CycleA `wire:""`
+}
+
+func (b *CycleB) Close() error {
+	b.recorder.record("b")
+	return nil
+}
+
+func TestDependencyOrderedClose(t *testing.T) {
+	t.Run("DependentsFirst", func(t *testing.T) {
+		r := &closeRecorder{}
+		application := ioc.RunTest(t, app.SetComponents(
+			&Repository{recorder: r},
+			&Cache{recorder: r},
+			&Dao{},
+			&Service{recorder: r},
+			&Handler{recorder: r},
+		))
+		application.Close()
+		assert.Len(t, r.closed, 4)
+		assert.Less(t, r.indexOf("handler"), r.indexOf("service"))
+		assert.Less(t, r.indexOf("service"), r.indexOf("repository"))
+		assert.Less(t, r.indexOf("service"), r.indexOf("cache"))
+	})
+	t.Run("CircularDependency", func(t *testing.T) {
+		var orders [][]string
+		for i := 0; i < 5; i++ {
+			r := &closeRecorder{}
+			application := ioc.RunTest(t, app.SetComponents(&CycleA{recorder: r}, &CycleB{recorder: r}))
+			application.Close()
+			assert.Len(t, r.closed, 2)
+			orders = append(orders, r.closed)
+		}
+		for _, order := range orders {
+			assert.Equal(t, orders[0], order)
+		}
+	})
+}
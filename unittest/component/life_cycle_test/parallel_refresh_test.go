@@ -0,0 +1,192 @@
+package life_cycle_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+type concurrencyMeter struct {
+	running atomic.Int32
+	max     atomic.Int32
+}
+
+func (m *concurrencyMeter) enter() {
+	n := m.running.Add(1)
+	for {
+		max := m.max.Load()
+		if n <= max || m.max.CompareAndSwap(max, n) {
+			return
+		}
+	}
+}
+
+func (m *concurrencyMeter) leave() {
+	m.running.Add(-1)
+}
+
+type initWorker interface {
+	Inited() bool
+}
+
+type slowWorker struct {
+	name   string
+	meter  *concurrencyMeter
+	inited atomic.Bool
+}
+
+func (w *slowWorker) Naming() string { return w.name }
+
+func (w *slowWorker) Init() error {
+	w.meter.enter()
+	defer w.meter.leave()
+	time.Sleep(50 * time.Millisecond)
+	w.inited.Store(true)
+	return nil
+}
+
+func (w *slowWorker) Inited() bool { return w.inited.Load() }
+
+type workerAggregator struct {
+	Workers       []initWorker `wire:""`
+	initedWorkers int
+}
+
+func (a *workerAggregator) Init() error {
+	for _, w := range a.Workers {
+		if w.Inited() {
+			a.initedWorkers++
+		}
+	}
+	return nil
+}
+
+// selfWorkerAggregator is an initWorker itself, it is left out of its Workers
+type selfWorkerAggregator struct {
+	workerAggregator
+}
+
+func (a *selfWorkerAggregator) Inited() bool { return true }
+
+type workerReport struct {
+	worker initWorker
+	inited bool
+}
+
+func newWorkerReport(w *slowWorker) *workerReport {
+	return &workerReport{worker: w, inited: w.Inited()}
+}
+
+// lazyWorker is created on first use, concurrently by the workers creating its holders
+type lazyWorker struct {
+	slowWorker
+}
+
+func (w *lazyWorker) LazyInit() {}
+
+type lazyWorkerHolder struct {
+	name   string
+	Worker *lazyWorker `wire:""`
+	inited bool
+}
+
+func (h *lazyWorkerHolder) Naming() string { return h.name }
+
+func (h *lazyWorkerHolder) Init() error {
+	h.inited = h.Worker.Inited()
+	return nil
+}
+
+type reportHolder struct {
+	Report *workerReport `wire:""`
+}
+
+type cycleA struct {
+	B *cycleB `wire:""`
+}
+
+type cycleB struct {
+	A *cycleA `wire:""`
+}
+
+// propertiesCounter counts the components whose properties it processes
+type propertiesCounter struct {
+	processors.DefaultInstantiationAwareComponentPostProcessor
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *propertiesCounter) PostProcessAfterInstantiation(component any, componentName string) (bool, error) {
+	return true, nil
+}
+
+func (c *propertiesCounter) PostProcessProperties(properties []*component_definition.Property, component any, componentName string) ([]*component_definition.Property, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[componentName]++
+	return nil, nil
+}
+
+func TestParallelRefresh(t *testing.T) {
+	t.Run("Concurrent", func(t *testing.T) {
+		meter := &concurrencyMeter{}
+		aggregator := &workerAggregator{}
+		var components []any
+		for _, name := range []string{"w1", "w2", "w3", "w4"} {
+			components = append(components, &slowWorker{name: name, meter: meter})
+		}
+		ioc.RunTest(t, app.LogError, app.ParallelRefresh(4), app.SetComponents(components...), app.SetComponents(aggregator))
+		assert.Greater(t, meter.max.Load(), int32(1))
+		assert.Equal(t, 4, aggregator.initedWorkers)
+	})
+	t.Run("Serial", func(t *testing.T) {
+		meter := &concurrencyMeter{}
+		ioc.RunTest(t, app.LogError, app.SetComponents(
+			&slowWorker{name: "w1", meter: meter},
+			&slowWorker{name: "w2", meter: meter},
+		))
+		assert.Equal(t, int32(1), meter.max.Load())
+	})
+	t.Run("Constructor", func(t *testing.T) {
+		holder := &reportHolder{}
+		ioc.RunTest(t, app.LogError, app.ParallelRefresh(2),
+			app.SetComponents(&slowWorker{name: "w1", meter: &concurrencyMeter{}}, newWorkerReport, holder),
+		)
+		assert.True(t, holder.Report.inited)
+	})
+	t.Run("LazyDependency", func(t *testing.T) {
+		worker := &lazyWorker{slowWorker{name: "lazy", meter: &concurrencyMeter{}}}
+		h1, h2 := &lazyWorkerHolder{name: "h1"}, &lazyWorkerHolder{name: "h2"}
+		ioc.RunTest(t, app.LogError, app.ParallelRefresh(2), app.SetComponents(worker, h1, h2))
+		assert.True(t, h1.inited)
+		assert.True(t, h2.inited)
+	})
+	t.Run("CircularReference", func(t *testing.T) {
+		a, b := &cycleA{}, &cycleB{}
+		ioc.RunTest(t, app.LogError, app.ParallelRefresh(2), app.SetComponents(a, b, &slowWorker{name: "w1", meter: &concurrencyMeter{}}))
+		assert.Same(t, b, a.B)
+		assert.Same(t, a, b.A)
+	})
+	t.Run("SelfInject", func(t *testing.T) {
+		meter := &concurrencyMeter{}
+		aggregator := &selfWorkerAggregator{}
+		ioc.RunTest(t, app.LogError, app.ParallelRefresh(2), app.SetComponents(aggregator,
+			&slowWorker{name: "w1", meter: meter}, &slowWorker{name: "w2", meter: meter}))
+		assert.Len(t, aggregator.Workers, 2)
+		assert.Equal(t, 2, aggregator.initedWorkers)
+	})
+	t.Run("PostProcessorsNotProbed", func(t *testing.T) {
+		counter := &propertiesCounter{calls: make(map[string]int)}
+		aggregator := &workerAggregator{}
+		ioc.RunTest(t, app.LogError, app.ParallelRefresh(2), app.SetComponents(counter, aggregator,
+			&slowWorker{name: "w1", meter: &concurrencyMeter{}}))
+		assert.Equal(t, 1, counter.calls["github.com/go-kid/ioc/unittest/component/life_cycle_test_test/workerAggregator"])
+	})
+}
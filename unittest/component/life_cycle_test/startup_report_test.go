@@ -0,0 +1,79 @@
+package life_cycle_test
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/stretchr/testify/assert"
+)
+
+type slowClient struct{}
+
+func (c *slowClient) Init() error {
+	time.Sleep(100 * time.Millisecond)
+	return nil
+}
+
+type slowPool struct{}
+
+func newSlowPool() *slowPool {
+	time.Sleep(20 * time.Millisecond)
+	return &slowPool{}
+}
+
+type reportedService struct {
+	Client *slowClient `wire:""`
+	Pool   *slowPool   `wire:""`
+}
+
+func findTiming(report *container.StartupReport, name string) (container.ComponentTiming, bool) {
+	for _, timing := range report.Components {
+		if strings.HasSuffix(timing.Name, "/"+name) {
+			return timing, true
+		}
+	}
+	return container.ComponentTiming{}, false
+}
+
+func TestStartupReport(t *testing.T) {
+	t.Run("Measured", func(t *testing.T) {
+		a := app.NewApp()
+		err := a.Run(app.LogError, app.MeasureStartup(), app.SetComponents(&reportedService{}, &slowClient{}, newSlowPool))
+		assert.NoError(t, err)
+
+		report := a.StartupReport()
+		assert.NotNil(t, report)
+		assert.True(t, slices.IsSortedFunc(report.Components, func(a, b container.ComponentTiming) int {
+			return cmp.Compare(b.Total, a.Total)
+		}), "components are sorted from the slowest")
+
+		client, ok := findTiming(report, "slowClient")
+		assert.True(t, ok)
+		assert.Positive(t, client.Init)
+		assert.LessOrEqual(t, client.Init, client.Total)
+		assert.Equal(t, 1, client.Creations)
+
+		pool, ok := findTiming(report, "slowPool")
+		assert.True(t, ok)
+		assert.Positive(t, pool.Instantiation)
+		assert.LessOrEqual(t, pool.Instantiation, pool.Total)
+
+		service, ok := findTiming(report, "reportedService")
+		assert.True(t, ok)
+		assert.Less(t, service.Total, client.Init, "the creation of dependencies is accounted to the dependencies")
+
+		assert.NotEmpty(t, report.PostProcessors)
+		assert.Contains(t, report.Table(3), "slowClient")
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		a := app.NewApp()
+		err := a.Run(app.LogError, app.SetComponents(&reportedService{}, &slowClient{}, newSlowPool))
+		assert.NoError(t, err)
+		assert.Nil(t, a.StartupReport())
+	})
+}
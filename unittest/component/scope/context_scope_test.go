@@ -0,0 +1,97 @@
+package scope
+
+import (
+	"context"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container/support"
+	"github.com/go-kid/ioc/util/framework_helper"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const scopeRequest = "request"
+
+var created, closed int32
+
+type Repository struct {
+}
+
+type RequestContext struct {
+	Repository *Repository `wire:""`
+	User       string
+}
+
+func (r *RequestContext) Scope() string { return scopeRequest }
+
+func (r *RequestContext) Init() error {
+	atomic.AddInt32(&created, 1)
+	return nil
+}
+
+func (r *RequestContext) Close() error {
+	atomic.AddInt32(&closed, 1)
+	return nil
+}
+
+type UnregisteredScopeComponent struct{}
+
+func (u *UnregisteredScopeComponent) Scope() string { return "unregistered" }
+
+func TestContextScope(t *testing.T) {
+	atomic.StoreInt32(&created, 0)
+	atomic.StoreInt32(&closed, 0)
+	var (
+		requestScope = support.NewContextScope(scopeRequest)
+		repository   = &Repository{}
+		template     = &RequestContext{User: "anonymous"}
+		name         = framework_helper.GetComponentName(template)
+	)
+	a := ioc.RunTest(t, app.SetScope(scopeRequest, requestScope), app.SetComponents(repository, template))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&created), "scoped component should not be created on refresh")
+
+	t.Run("NotActive", func(t *testing.T) {
+		_, err := a.GetComponentByNameWithContext(context.Background(), name)
+		assert.Error(t, err)
+	})
+
+	t.Run("OneInstancePerContext", func(t *testing.T) {
+		ctx1, cancel1 := context.WithCancel(context.Background())
+		ctx1 = requestScope.Begin(ctx1)
+		ctx2, cancel2 := context.WithCancel(context.Background())
+		ctx2 = requestScope.Begin(ctx2)
+
+		c1, err := a.GetComponentByNameWithContext(ctx1, name)
+		assert.NoError(t, err)
+		c1Again, err := a.GetComponentByNameWithContext(ctx1, name)
+		assert.NoError(t, err)
+		c2, err := a.GetComponentByNameWithContext(ctx2, name)
+		assert.NoError(t, err)
+
+		assert.Same(t, c1, c1Again)
+		assert.NotSame(t, c1, c2)
+		assert.NotSame(t, template, c1)
+		assert.Same(t, repository, c1.(*RequestContext).Repository)
+		assert.Empty(t, c1.(*RequestContext).User, "scoped instances are created from the zero value, not copied")
+		assert.Nil(t, template.Repository)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&created))
+
+		cancel1()
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&closed) == 1 }, time.Second, time.Millisecond)
+		assert.False(t, requestScope.IsActive(ctx1))
+		_, err = a.GetComponentByNameWithContext(ctx1, name)
+		assert.Error(t, err)
+
+		cancel2()
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&closed) == 2 }, time.Second, time.Millisecond)
+	})
+}
+
+func TestUnregisteredScope(t *testing.T) {
+	c := &UnregisteredScopeComponent{}
+	a := ioc.RunTest(t, app.SetComponents(c))
+	_, err := a.GetComponentByName(framework_helper.GetComponentName(c))
+	assert.Error(t, err)
+}
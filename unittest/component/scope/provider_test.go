@@ -0,0 +1,83 @@
+package scope
+
+import (
+	"context"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/container/support"
+	"github.com/go-kid/ioc/definition"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type Session struct {
+	ID string
+}
+
+func (s *Session) Scope() string { return scopeRequest }
+
+type Counter struct {
+	N int
+}
+
+func (c *Counter) Scope() string { return definition.ScopePrototype }
+
+var counter int
+
+func NewCounter() *Counter {
+	counter++
+	return &Counter{N: counter}
+}
+
+type Handler struct {
+	Session container.Provider[*Session] `wire:""`
+	Counter container.Provider[*Counter] `wire:""`
+	Missing container.Provider[*Handler] `wire:"missing,required=false"`
+}
+
+func TestProvider(t *testing.T) {
+	counter = 0
+	var (
+		requestScope = support.NewContextScope(scopeRequest)
+		handler      = &Handler{}
+	)
+	ioc.RunTest(t, app.SetScope(scopeRequest, requestScope), app.SetComponents(handler, &Session{}, NewCounter))
+
+	t.Run("Prototype", func(t *testing.T) {
+		c1, err := handler.Counter.Get()
+		assert.NoError(t, err)
+		c2 := handler.Counter.MustGet()
+		assert.NotSame(t, c1, c2)
+		assert.NotEqual(t, c1.N, c2.N)
+	})
+
+	t.Run("ContextScope", func(t *testing.T) {
+		_, err := handler.Session.Get()
+		assert.Error(t, err)
+
+		ctx1, cancel1 := context.WithCancel(context.Background())
+		defer cancel1()
+		ctx1 = requestScope.Begin(ctx1)
+		ctx2, cancel2 := context.WithCancel(context.Background())
+		defer cancel2()
+		ctx2 = requestScope.Begin(ctx2)
+
+		s1, err := handler.Session.GetWithContext(ctx1)
+		assert.NoError(t, err)
+		s1Again, err := handler.Session.GetWithContext(ctx1)
+		assert.NoError(t, err)
+		s2, err := handler.Session.GetWithContext(ctx2)
+		assert.NoError(t, err)
+		assert.Same(t, s1, s1Again)
+		assert.NotSame(t, s1, s2)
+	})
+
+	t.Run("Unbound", func(t *testing.T) {
+		_, err := handler.Missing.Get()
+		assert.Error(t, err)
+		assert.Panics(t, func() {
+			handler.Missing.MustGet()
+		})
+	})
+}
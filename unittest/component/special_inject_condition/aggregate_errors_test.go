@@ -0,0 +1,126 @@
+package special_inject_condition
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/stretchr/testify/assert"
+)
+
+type aggregateRepo struct {
+	Mailer mailer `wire:""`
+	DSN    string `value:"${aggregate.dsn}"`
+}
+
+type aggregateService struct {
+	Repo *aggregateRepo `wire:""`
+}
+
+type aggregateHandler struct {
+	Service *aggregateService `wire:""`
+}
+
+type aggregateHealthy struct {
+	initialized bool
+}
+
+func (h *aggregateHealthy) Init() error {
+	h.initialized = true
+	return nil
+}
+
+type aggregateClient struct{}
+
+func newAggregateClient(m mailer) *aggregateClient { return &aggregateClient{} }
+
+func shortName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+func refreshErrorOf(t *testing.T, err error) *container.RefreshError {
+	var refreshErr *container.RefreshError
+	if !assert.True(t, errors.As(err, &refreshErr)) {
+		return nil
+	}
+	return refreshErr
+}
+
+func TestAggregateErrors(t *testing.T) {
+	run := func(t *testing.T, healthy *aggregateHealthy, ops ...app.SettingOption) *container.RefreshError {
+		err := app.NewApp().Run(append([]app.SettingOption{app.LogError, app.AggregateErrors(),
+			app.SetComponents(&aggregateRepo{}, &aggregateService{}, &aggregateHandler{}, healthy, newAggregateClient),
+		}, ops...)...)
+		return refreshErrorOf(t, err)
+	}
+	t.Run("FailuresAndSkipped", func(t *testing.T) {
+		healthy := &aggregateHealthy{}
+		refreshErr := run(t, healthy)
+		if refreshErr == nil {
+			return
+		}
+		assert.True(t, healthy.initialized)
+
+		failures := make(map[string]container.ComponentError)
+		for _, failure := range refreshErr.Failures {
+			failures[shortName(failure.Component)+"."+failure.Field] = failure
+		}
+		assert.Len(t, failures, 3)
+		if mailerErr, ok := failures["aggregateRepo.Mailer"]; assert.True(t, ok) {
+			assert.Equal(t, `wire:""`, mailerErr.Tag)
+			assert.Equal(t, []string{"aggregateHandler", "aggregateService", "aggregateRepo"}, shortNames(mailerErr.Chain))
+		}
+		if dsnErr, ok := failures["aggregateRepo.DSN"]; assert.True(t, ok) {
+			assert.Contains(t, dsnErr.Reason, "aggregate.dsn")
+		}
+		assert.Contains(t, failures, "aggregateClient.param[0]")
+
+		var skipped []string
+		for _, s := range refreshErr.Skipped {
+			skipped = append(skipped, shortName(s.Component)+"."+s.Field+"->"+shortName(s.Dependency))
+		}
+		assert.ElementsMatch(t, []string{
+			"aggregateService.Repo->aggregateRepo",
+			"aggregateHandler.Service->aggregateService",
+		}, skipped)
+		assert.Contains(t, refreshErr.Error(), "3 components failed, 2 skipped")
+	})
+	t.Run("JSON", func(t *testing.T) {
+		refreshErr := run(t, &aggregateHealthy{})
+		if refreshErr == nil {
+			return
+		}
+		data, err := json.Marshal(refreshErr)
+		assert.NoError(t, err)
+		var decoded container.RefreshError
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, *refreshErr, decoded)
+	})
+	t.Run("Parallel", func(t *testing.T) {
+		healthy := &aggregateHealthy{}
+		refreshErr := run(t, healthy, app.ParallelRefresh(4))
+		if refreshErr == nil {
+			return
+		}
+		assert.True(t, healthy.initialized)
+		assert.Len(t, refreshErr.Failures, 3)
+		assert.Len(t, refreshErr.Skipped, 2)
+	})
+	t.Run("FailFast", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.SetComponents(&aggregateRepo{}, &aggregateService{}))
+		assert.Error(t, err)
+		var refreshErr *container.RefreshError
+		assert.False(t, errors.As(err, &refreshErr))
+	})
+}
+
+func shortNames(names []string) []string {
+	short := make([]string, len(names))
+	for i, name := range names {
+		short[i] = shortName(name)
+	}
+	return short
+}
@@ -0,0 +1,99 @@
+package special_inject_condition
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/container/support"
+	"github.com/stretchr/testify/assert"
+)
+
+type httpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type idleCloser interface {
+	CloseIdleConnections()
+}
+
+// plainRegistry hides the optional methods of the wrapped registry
+type plainRegistry struct {
+	container.SingletonRegistry
+}
+
+func TestNamedComponent(t *testing.T) {
+	internal := &http.Client{}
+	external := &http.Client{}
+
+	t.Run("ByName", func(t *testing.T) {
+		type T struct {
+			Internal *http.Client `wire:"internalClient"`
+			External *http.Client `wire:"externalClient"`
+		}
+		tt := &T{}
+		ioc.RunTest(t,
+			app.SetComponents(tt),
+			app.SetNamedComponent("internalClient", internal),
+			app.SetNamedComponent("externalClient", external),
+		)
+		assert.Same(t, internal, tt.Internal)
+		assert.Same(t, external, tt.External)
+	})
+	t.Run("Primary", func(t *testing.T) {
+		type T struct {
+			Client *http.Client `wire:""`
+		}
+		tt := &T{}
+		ioc.RunTest(t,
+			app.LogError,
+			app.StrictWiring(),
+			app.SetComponents(tt),
+			app.SetNamedComponent("internalClient", internal),
+			app.SetNamedComponent("externalClient", external, app.AsPrimary()),
+		)
+		assert.Same(t, external, tt.Client)
+	})
+	t.Run("Qualifier", func(t *testing.T) {
+		type T struct {
+			Client *http.Client `wire:",qualifier=internal"`
+		}
+		tt := &T{}
+		ioc.RunTest(t,
+			app.SetComponents(tt),
+			app.SetNamedComponent("internalClient", internal, app.WithQualifier("internal")),
+			app.SetNamedComponent("externalClient", external, app.WithQualifier("external")),
+		)
+		assert.Same(t, internal, tt.Client)
+	})
+	t.Run("AsInterface", func(t *testing.T) {
+		type T struct {
+			Doer   httpDoer     `wire:""`
+			Closer idleCloser   `wire:""`
+			Client *http.Client `wire:",required=false"`
+		}
+		tt := &T{}
+		ioc.RunTest(t,
+			app.StrictWiring(),
+			app.SetComponents(tt),
+			app.SetNamedComponent("internalClient", internal, app.As[httpDoer]()),
+			app.SetNamedComponent("externalClient", external, app.As[idleCloser]()),
+		)
+		assert.Same(t, internal, tt.Doer)
+		assert.Same(t, external, tt.Closer)
+		assert.Nil(t, tt.Client)
+	})
+	t.Run("NotAssignable", func(t *testing.T) {
+		assert.Panics(t, func() {
+			app.NewApp().Run(app.SetNamedComponent("client", internal, app.As[IdComp]()))
+		})
+	})
+	t.Run("UnsupportedRegistry", func(t *testing.T) {
+		err := app.NewApp().Validate(app.LogError,
+			app.SetRegistry(plainRegistry{support.NewRegistry()}),
+			app.SetNamedComponent("client", internal))
+		assert.ErrorContains(t, err, "does not support named registrations")
+	})
+}
@@ -0,0 +1,72 @@
+package special_inject_condition
+
+import (
+	"testing"
+
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/go-kid/ioc/container/support"
+	"github.com/stretchr/testify/assert"
+)
+
+type mailer interface {
+	Send() string
+}
+
+type smtpMailer struct{ host string }
+
+func (m *smtpMailer) Send() string { return "smtp:" + m.host }
+
+type stubMailer struct{ sent int }
+
+func (m *stubMailer) Send() string { m.sent++; return "stub" }
+
+type mailService struct {
+	Mailer mailer `wire:""`
+}
+
+func TestReplaceComponent(t *testing.T) {
+	t.Run("Replace", func(t *testing.T) {
+		svc := &mailService{}
+		ioc.RunTest(t,
+			app.ReplaceComponent(&smtpMailer{}, &stubMailer{}),
+			app.SetComponents(svc, &smtpMailer{host: "localhost"}),
+		)
+		assert.Equal(t, "stub", svc.Mailer.Send())
+	})
+	t.Run("LastWins", func(t *testing.T) {
+		svc := &mailService{}
+		ioc.RunTest(t,
+			app.SetOverridePolicy(container.OverrideLastWins),
+			app.SetComponents(svc, &smtpMailer{host: "default"}, &smtpMailer{host: "test"}),
+		)
+		assert.Equal(t, "smtp:test", svc.Mailer.Send())
+	})
+	t.Run("FirstWins", func(t *testing.T) {
+		svc := &mailService{}
+		ioc.RunTest(t,
+			app.SetOverridePolicy(container.OverrideFirstWins),
+			app.SetComponents(svc, &smtpMailer{host: "default"}, &smtpMailer{host: "test"}),
+		)
+		assert.Equal(t, "smtp:default", svc.Mailer.Send())
+	})
+	t.Run("Error", func(t *testing.T) {
+		ioc.RunErrorTest(t,
+			app.LogError,
+			app.SetOverridePolicy(container.OverrideError),
+			app.SetComponents(&mailService{}, &smtpMailer{host: "default"}, &smtpMailer{host: "test"}),
+		)
+	})
+	t.Run("Panic", func(t *testing.T) {
+		assert.Panics(t, func() {
+			app.NewApp().Run(app.SetComponents(&smtpMailer{host: "default"}, &smtpMailer{host: "test"}))
+		})
+	})
+	t.Run("UnsupportedRegistry", func(t *testing.T) {
+		err := app.NewApp().Validate(app.LogError,
+			app.SetRegistry(plainRegistry{support.NewRegistry()}),
+			app.ReplaceComponent(&smtpMailer{}, &stubMailer{}))
+		assert.ErrorContains(t, err, "does not support overriding components")
+	})
+}
@@ -0,0 +1,95 @@
+package special_inject_condition
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/container"
+	"github.com/stretchr/testify/assert"
+)
+
+type resolutionHandler struct {
+	Service *resolutionService `wire:""`
+}
+
+type resolutionService struct {
+	Repo *resolutionRepo `wire:""`
+}
+
+type resolutionRepo struct {
+	Mailer mailer `wire:""`
+	DSN    string `value:"${resolution.dsn}"`
+}
+
+type resolutionNotifier struct{}
+
+func newResolutionNotifier(m mailer) *resolutionNotifier { return &resolutionNotifier{} }
+
+type resolutionClient struct{}
+
+var errResolutionDial = errors.New("dial failed")
+
+func newResolutionClient() (*resolutionClient, error) { return nil, errResolutionDial }
+
+func TestResolutionErrors(t *testing.T) {
+	t.Run("NoSuchComponent", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.SetComponents(&resolutionHandler{}, &resolutionService{}))
+		var noSuchErr *container.NoSuchComponentError
+		if assert.True(t, errors.As(err, &noSuchErr)) {
+			assert.Equal(t, "Repo", noSuchErr.Field)
+			assert.Equal(t, reflect.TypeOf(&resolutionRepo{}), noSuchErr.Type)
+			assert.Equal(t, []string{"resolutionHandler", "resolutionService"}, shortNames(noSuchErr.Chain))
+		}
+	})
+	t.Run("NoSuchQualifiedComponent", func(t *testing.T) {
+		type T struct {
+			Comp IdComp `wire:",qualifier=q3"`
+		}
+		err := app.NewApp().Run(app.LogError, app.SetComponents(
+			&strictComponent{idComp: idComp{id: 1}, namingComponent: namingComponent{name: "strict1"}, qualifier: "q1"},
+			&T{},
+		))
+		var noSuchErr *container.NoSuchComponentError
+		if assert.True(t, errors.As(err, &noSuchErr)) {
+			assert.Equal(t, []string{"q3"}, noSuchErr.Qualifiers)
+		}
+	})
+	t.Run("NoSuchConstructorParam", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.SetComponents(newResolutionNotifier))
+		var noSuchErr *container.NoSuchComponentError
+		if assert.True(t, errors.As(err, &noSuchErr)) {
+			assert.Equal(t, "param[0]", noSuchErr.Field)
+			assert.Equal(t, "resolutionNotifier", shortName(noSuchErr.Component))
+		}
+	})
+	t.Run("Ambiguous", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.StrictWiring(), app.SetComponents(
+			&strictComponent{idComp: idComp{id: 1}, namingComponent: namingComponent{name: "strict1"}},
+			&strictComponent{idComp: idComp{id: 2}, namingComponent: namingComponent{name: "strict2"}},
+			newStrictConsumer,
+		))
+		var ambiguousErr *container.AmbiguousComponentError
+		if assert.True(t, errors.As(err, &ambiguousErr)) {
+			assert.Equal(t, "param[0]", ambiguousErr.Field)
+			assert.Equal(t, []string{"strict1", "strict2"}, ambiguousErr.Candidates)
+		}
+	})
+	t.Run("MissingConfig", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.SetComponents(&resolutionRepo{}, &stubMailer{}))
+		var missingErr *container.MissingConfigError
+		if assert.True(t, errors.As(err, &missingErr)) {
+			assert.Equal(t, "DSN", missingErr.Field)
+			assert.Equal(t, []string{"resolution.dsn"}, missingErr.Keys)
+		}
+	})
+	t.Run("ConstructorFailed", func(t *testing.T) {
+		err := app.NewApp().Run(app.LogError, app.SetComponents(newResolutionClient))
+		var constructorErr *container.ConstructorError
+		if assert.True(t, errors.As(err, &constructorErr)) {
+			assert.Equal(t, "resolutionClient", shortName(constructorErr.Component))
+		}
+		assert.ErrorIs(t, err, errResolutionDial)
+	})
+}
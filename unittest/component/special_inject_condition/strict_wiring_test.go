@@ -0,0 +1,89 @@
+package special_inject_condition
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type strictComponent struct {
+	idComp
+	namingComponent
+	qualifier string
+}
+
+func (s *strictComponent) Qualifier() string {
+	return s.qualifier
+}
+
+type strictConsumer struct {
+	Comp IdComp
+}
+
+func newStrictConsumer(comp IdComp) *strictConsumer {
+	return &strictConsumer{Comp: comp}
+}
+
+func TestStrictWiring(t *testing.T) {
+	newData := func() []any {
+		return []any{
+			&strictComponent{idComp: idComp{id: 1}, namingComponent: namingComponent{name: "strict1"}, qualifier: "q1"},
+			&strictComponent{idComp: idComp{id: 2}, namingComponent: namingComponent{name: "strict2"}, qualifier: "q2"},
+		}
+	}
+	t.Run("AmbiguousField", func(t *testing.T) {
+		type T struct {
+			Comp IdComp `wire:""`
+		}
+		a := app.NewApp()
+		err := a.Run(app.LogError, app.StrictWiring(), app.SetComponents(newData()...), app.SetComponents(&T{}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "strict1")
+		assert.Contains(t, err.Error(), "strict2")
+	})
+	t.Run("AmbiguousNotRequiredField", func(t *testing.T) {
+		type T struct {
+			Comp IdComp `wire:",required=false"`
+		}
+		ioc.RunErrorTest(t, app.LogError, app.StrictWiring(), app.SetComponents(newData()...), app.SetComponents(&T{}))
+	})
+	t.Run("QualifiedField", func(t *testing.T) {
+		type T struct {
+			Comp IdComp `wire:",qualifier=q2"`
+		}
+		tt := &T{}
+		ioc.RunTest(t, app.LogError, app.StrictWiring(), app.SetComponents(newData()...), app.SetComponents(tt))
+		assert.Equal(t, 2, tt.Comp.Id())
+	})
+	t.Run("PrimaryField", func(t *testing.T) {
+		type T struct {
+			Comp IdComp `wire:""`
+		}
+		tt := &T{}
+		ioc.RunTest(t, app.LogError, app.StrictWiring(), app.SetComponents(newData()...), app.SetComponents(tt,
+			&PrimaryComponent{idComp: idComp{id: 0}, namingComponent: namingComponent{name: "primary"}}))
+		assert.Equal(t, 0, tt.Comp.Id())
+	})
+	t.Run("SliceField", func(t *testing.T) {
+		type T struct {
+			Comps []IdComp `wire:""`
+		}
+		tt := &T{}
+		ioc.RunTest(t, app.LogError, app.StrictWiring(), app.SetComponents(newData()...), app.SetComponents(tt))
+		assert.Len(t, tt.Comps, 2)
+	})
+	t.Run("AmbiguousConstructorParam", func(t *testing.T) {
+		a := app.NewApp()
+		err := a.Run(app.LogError, app.StrictWiring(), app.SetComponents(newData()...), app.SetComponents(newStrictConsumer))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "strict1")
+		assert.Contains(t, err.Error(), "strict2")
+	})
+	t.Run("NotStrict", func(t *testing.T) {
+		type T struct {
+			Comp IdComp `wire:""`
+		}
+		ioc.RunTest(t, app.LogError, app.SetComponents(newData()...), app.SetComponents(&T{}, newStrictConsumer))
+	})
+}
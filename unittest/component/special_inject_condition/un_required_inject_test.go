@@ -3,6 +3,7 @@ package special_inject_condition
 import (
 	"github.com/go-kid/ioc"
 	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -51,3 +52,21 @@ func TestUnRequiredInject(t *testing.T) {
 		ioc.RunTest(t, app.SetComponents(tt))
 	})
 }
+
+type unRequiredMissing interface {
+	missing()
+}
+
+func TestUnRequiredInjectFollowedByRequired(t *testing.T) {
+	type T struct {
+		Missing unRequiredMissing `wire:",required=false"`
+		Comps   []ITest           `wire:",qualifier=group1"`
+	}
+	var tt = &T{}
+	ioc.RunTest(t, app.SetComponents(tt,
+		&QualifierComponent{name: "test11", group: "group1"},
+		&QualifierComponent{name: "test21", group: "group2"},
+	))
+	assert.Nil(t, tt.Missing)
+	assert.Len(t, tt.Comps, 1)
+}
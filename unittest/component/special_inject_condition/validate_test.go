@@ -0,0 +1,121 @@
+package special_inject_condition
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/go-kid/ioc/container"
+	"github.com/stretchr/testify/assert"
+)
+
+type validateStore interface {
+	Load() string
+}
+
+type validateFileStore struct{}
+
+func (s *validateFileStore) Load() string { return "file" }
+
+type validateMemoryStore struct{}
+
+func (s *validateMemoryStore) Load() string { return "memory" }
+
+type validateService struct {
+	initialized bool
+	Store       validateStore `wire:""`
+	Mailer      mailer        `wire:""`
+	DSN         string        `value:"${validate.dsn}"`
+	Timeout     string        `value:"${validate.timeout:5s}"`
+}
+
+func (s *validateService) Init() error {
+	s.initialized = true
+	return nil
+}
+
+type validateProducer struct{ consumer *validateConsumer }
+
+type validateConsumer struct{ producer *validateProducer }
+
+func newValidateProducer(c *validateConsumer) *validateProducer {
+	return &validateProducer{consumer: c}
+}
+
+func newValidateConsumer(p *validateProducer) *validateConsumer {
+	return &validateConsumer{producer: p}
+}
+
+func problemsOf(t *testing.T, err error) map[container.WiringProblemKind][]container.WiringProblem {
+	var validationErr *container.ValidationError
+	if !assert.True(t, errors.As(err, &validationErr)) {
+		return nil
+	}
+	problems := make(map[container.WiringProblemKind][]container.WiringProblem)
+	for _, problem := range validationErr.Problems {
+		problems[problem.Kind] = append(problems[problem.Kind], problem)
+	}
+	return problems
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("AllProblems", func(t *testing.T) {
+		svc := &validateService{}
+		err := app.NewApp().Validate(app.LogError, app.StrictWiring(),
+			app.SetComponents(svc, &validateFileStore{}, &validateMemoryStore{}),
+			app.SetComponents(newValidateProducer, newValidateConsumer),
+		)
+		problems := problemsOf(t, err)
+		assert.False(t, svc.initialized)
+
+		if assert.Len(t, problems[container.ProblemUnresolved], 1) {
+			assert.Equal(t, "Mailer", problems[container.ProblemUnresolved][0].Field)
+		}
+		if assert.Len(t, problems[container.ProblemAmbiguous], 1) {
+			assert.Equal(t, "Store", problems[container.ProblemAmbiguous][0].Field)
+		}
+		if assert.Len(t, problems[container.ProblemMissingConfig], 1) {
+			assert.Equal(t, "DSN", problems[container.ProblemMissingConfig][0].Field)
+			assert.Contains(t, problems[container.ProblemMissingConfig][0].Reason, "validate.dsn")
+		}
+		if assert.Len(t, problems[container.ProblemCycle], 1) {
+			assert.Contains(t, problems[container.ProblemCycle][0].Reason, "validateConsumer -> ")
+			assert.Contains(t, problems[container.ProblemCycle][0].Reason, "validateProducer -> ")
+		}
+	})
+	t.Run("ConstructorParams", func(t *testing.T) {
+		type consumer struct{}
+		err := app.NewApp().Validate(app.LogError, app.StrictWiring(),
+			app.SetComponents(func(s validateStore, m mailer) *consumer { return &consumer{} }),
+			app.SetComponents(&validateFileStore{}, &validateMemoryStore{}),
+		)
+		problems := problemsOf(t, err)
+		if assert.Len(t, problems[container.ProblemAmbiguous], 1) {
+			assert.Equal(t, "param[0]", problems[container.ProblemAmbiguous][0].Field)
+		}
+		if assert.Len(t, problems[container.ProblemUnresolved], 1) {
+			assert.Equal(t, "param[1]", problems[container.ProblemUnresolved][0].Field)
+		}
+	})
+	t.Run("DefaultWiringMode", func(t *testing.T) {
+		type consumer struct{}
+		svc := &validateService{}
+		err := app.NewApp().Validate(app.LogError,
+			app.SetConfigLoader(loader.NewRawLoader([]byte("validate:\n  dsn: mysql://localhost"))),
+			app.SetComponents(svc, &stubMailer{}, func(s validateStore) *consumer { return &consumer{} }),
+			app.SetComponents(&validateFileStore{}, &validateMemoryStore{}),
+		)
+		assert.NoError(t, err, "several candidates are resolved by the best one like Run does")
+	})
+	t.Run("Valid", func(t *testing.T) {
+		svc := &validateService{}
+		err := app.NewApp().Validate(app.LogError,
+			app.SetConfigLoader(loader.NewRawLoader([]byte("validate:\n  dsn: mysql://localhost"))),
+			app.SetComponents(svc, &validateFileStore{}, &stubMailer{}),
+		)
+		assert.NoError(t, err)
+		assert.False(t, svc.initialized)
+		assert.Nil(t, svc.Store)
+	})
+}